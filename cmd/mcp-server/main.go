@@ -82,19 +82,33 @@ func initConfig() error {
 	// Manually bind OpenStack environment variables
 	// Supports both standard OS_* and OSMCP_OS_* prefixes
 	envBindings := map[string][]string{
+		"openstack.cloud":               {"OS_CLOUD", "OSMCP_OS_CLOUD"},
 		"openstack.auth_url":            {"OS_AUTH_URL", "OSMCP_OS_AUTH_URL"},
+		"openstack.auth_type":           {"OS_AUTH_TYPE", "OSMCP_OS_AUTH_TYPE"},
 		"openstack.username":            {"OS_USERNAME", "OSMCP_OS_USERNAME"},
+		"openstack.user_id":             {"OS_USER_ID", "OSMCP_OS_USER_ID"},
 		"openstack.password":            {"OS_PASSWORD", "OSMCP_OS_PASSWORD"},
+		"openstack.passcode":            {"OS_PASSCODE", "OSMCP_OS_PASSCODE"},
+		"openstack.token":               {"OS_TOKEN", "OSMCP_OS_TOKEN"},
 		"openstack.project_name":        {"OS_PROJECT_NAME", "OSMCP_OS_PROJECT_NAME"},
 		"openstack.project_id":          {"OS_PROJECT_ID", "OSMCP_OS_PROJECT_ID"},
 		"openstack.project_domain_name": {"OS_PROJECT_DOMAIN_NAME", "OSMCP_OS_PROJECT_DOMAIN_NAME"},
+		"openstack.project_domain_id":   {"OS_PROJECT_DOMAIN_ID", "OSMCP_OS_PROJECT_DOMAIN_ID"},
 		"openstack.user_domain_name":    {"OS_USER_DOMAIN_NAME", "OSMCP_OS_USER_DOMAIN_NAME"},
+		"openstack.user_domain_id":      {"OS_USER_DOMAIN_ID", "OSMCP_OS_USER_DOMAIN_ID"},
 		"openstack.region":              {"OS_REGION_NAME", "OSMCP_OS_REGION_NAME"},
 		"openstack.endpoint_type":       {"OS_ENDPOINT_TYPE", "OSMCP_OS_ENDPOINT_TYPE"},
 		"openstack.ca_cert_file":        {"OS_CACERT", "OSMCP_OS_CACERT"},
+		"openstack.client_cert_file":    {"OS_CERT", "OSMCP_OS_CERT"},
+		"openstack.client_key_file":     {"OS_KEY", "OSMCP_OS_KEY"},
 		"openstack.verify_ssl":          {"OS_VERIFY_SSL", "OSMCP_OS_VERIFY_SSL"},
 		"openstack.timeout":             {"OS_TIMEOUT", "OSMCP_OS_TIMEOUT"},
 		"openstack.max_retries":         {"OS_MAX_RETRIES", "OSMCP_OS_MAX_RETRIES"},
+
+		// Application credentials
+		"openstack.application_credential_id":     {"OS_APPLICATION_CREDENTIAL_ID", "OSMCP_OS_APPLICATION_CREDENTIAL_ID"},
+		"openstack.application_credential_name":   {"OS_APPLICATION_CREDENTIAL_NAME", "OSMCP_OS_APPLICATION_CREDENTIAL_NAME"},
+		"openstack.application_credential_secret": {"OS_APPLICATION_CREDENTIAL_SECRET", "OSMCP_OS_APPLICATION_CREDENTIAL_SECRET"},
 	}
 	for key, envVars := range envBindings {
 		// viper.BindEnv takes key as first arg, then env var names
@@ -107,10 +121,35 @@ func initConfig() error {
 	// Bind MCP settings with OSMCP_ prefix (without _MCP_ in the middle)
 	mcpBindings := map[string]string{
 		"mcp.read_only":         "OSMCP_READONLY",
+		"mcp.dry_run":           "OSMCP_DRY_RUN",
 		"mcp.transport.type":    "OSMCP_TRANSPORT_TYPE",
 		"mcp.transport.host":    "OSMCP_TRANSPORT_HOST",
 		"mcp.transport.port":    "OSMCP_TRANSPORT_PORT",
 		"mcp.transport.timeout": "OSMCP_TRANSPORT_TIMEOUT",
+
+		"mcp.transport.per_request_credentials": "OSMCP_TRANSPORT_PER_REQUEST_CREDENTIALS",
+		"mcp.transport.auth.type":               "OSMCP_TRANSPORT_AUTH_TYPE",
+		"mcp.transport.auth.tokens_file":        "OSMCP_TRANSPORT_AUTH_TOKENS_FILE",
+		"mcp.transport.auth.issuer":             "OSMCP_TRANSPORT_AUTH_ISSUER",
+		"mcp.transport.auth.jwks_url":           "OSMCP_TRANSPORT_AUTH_JWKS_URL",
+		"mcp.transport.auth.audience":           "OSMCP_TRANSPORT_AUTH_AUDIENCE",
+		"mcp.transport.auth.resource_url":       "OSMCP_TRANSPORT_AUTH_RESOURCE_URL",
+		"mcp.transport.auth.roles_claim":        "OSMCP_TRANSPORT_AUTH_ROLES_CLAIM",
+		"mcp.transport.tls_cert_file":           "OSMCP_TRANSPORT_TLS_CERT_FILE",
+		"mcp.transport.tls_key_file":            "OSMCP_TRANSPORT_TLS_KEY_FILE",
+		"mcp.transport.tls_client_ca_file":      "OSMCP_TRANSPORT_TLS_CLIENT_CA_FILE",
+		"mcp.policy.allow":                      "OSMCP_POLICY_ALLOW",
+		"mcp.policy.deny":                       "OSMCP_POLICY_DENY",
+		"mcp.policy.require_confirmation":       "OSMCP_POLICY_REQUIRE_CONFIRMATION",
+		"mcp.policy.allow_sensitive":            "OSMCP_POLICY_ALLOW_SENSITIVE",
+		"mcp.audit.enabled":                     "OSMCP_AUDIT_ENABLED",
+		"mcp.audit.output":                      "OSMCP_AUDIT_OUTPUT",
+		"mcp.audit.read_sample_rate":            "OSMCP_AUDIT_READ_SAMPLE_RATE",
+		"mcp.confirmation.destructive":          "OSMCP_CONFIRMATION_DESTRUCTIVE",
+		"mcp.confirmation.fallback":             "OSMCP_CONFIRMATION_FALLBACK",
+		"mcp.subscriptions.poll_interval":       "OSMCP_SUBSCRIPTIONS_POLL_INTERVAL",
+		"mcp.subscriptions.max_per_session":     "OSMCP_SUBSCRIPTIONS_MAX_PER_SESSION",
+		"mcp.prompts.files":                     "OSMCP_PROMPTS_FILES",
 	}
 	for key, env := range mcpBindings {
 		if err := viper.BindEnv(key, env); err != nil {
@@ -133,27 +172,69 @@ func initConfig() error {
 		}
 	}
 
+	// Load the named cloud last, once flags, env and config file can all name it
+	if cloud := viper.GetString("openstack.cloud"); cloud != "" {
+		if err := loadCloudDefaults(cloud); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// loadCloudDefaults layers the named clouds.yaml entry below flags, env vars
+// and the config file by registering its values as viper defaults
+func loadCloudDefaults(cloud string) error {
+	settings, err := config.LoadCloud(cloud)
+	if err != nil {
+		return fmt.Errorf("loading cloud %q: %w", cloud, err)
+	}
+	for key, value := range settings {
+		viper.SetDefault("openstack."+key, value)
+	}
+	return nil
+}
+
+// openStackDefaults are the default openstack.* settings, also used as the
+// base of clouds entries that describe a different cloud
+var openStackDefaults = map[string]interface{}{
+	"endpoint_type":       "public",
+	"timeout":             30 * time.Second,
+	"max_retries":         3,
+	"verify_ssl":          true,
+	"user_domain_name":    "Default",
+	"project_domain_name": "Default",
+}
+
+// connectionSettings are the openstack.* settings inherited by every clouds
+// entry, even those that point to a different cloud
+var connectionSettings = []string{"endpoint_type", "timeout", "max_retries", "verify_ssl"}
+
 // setDefaults sets default values in viper
 func setDefaults() {
 	// OpenStack defaults
-	viper.SetDefault("openstack.endpoint_type", "public")
-	viper.SetDefault("openstack.timeout", 30*time.Second)
-	viper.SetDefault("openstack.max_retries", 3)
-	viper.SetDefault("openstack.verify_ssl", true)
-	viper.SetDefault("openstack.user_domain_name", "Default")
-	viper.SetDefault("openstack.project_domain_name", "Default")
+	for key, value := range openStackDefaults {
+		viper.SetDefault("openstack."+key, value)
+	}
 
 	// MCP defaults
 	viper.SetDefault("mcp.transport.type", "stdio")
 	viper.SetDefault("mcp.transport.port", 8080)
 	viper.SetDefault("mcp.transport.host", "localhost")
 	viper.SetDefault("mcp.transport.timeout", 30*time.Second)
+	viper.SetDefault("mcp.transport.per_request_credentials", false)
+	viper.SetDefault("mcp.transport.auth.type", "none")
+	viper.SetDefault("mcp.audit.enabled", false)
+	viper.SetDefault("mcp.audit.output", "stderr")
+	viper.SetDefault("mcp.audit.read_sample_rate", 0.0)
+	viper.SetDefault("mcp.confirmation.destructive", true)
+	viper.SetDefault("mcp.confirmation.fallback", "argument")
+	viper.SetDefault("mcp.subscriptions.poll_interval", 30*time.Second)
+	viper.SetDefault("mcp.subscriptions.max_per_session", 100)
 	viper.SetDefault("mcp.server_name", "openstack-mcp-server")
 	viper.SetDefault("mcp.server_version", "0.1.0")
 	viper.SetDefault("mcp.read_only", false)
+	viper.SetDefault("mcp.dry_run", false)
 
 	// Logging defaults
 	viper.SetDefault("logging.level", "info")
@@ -172,6 +253,13 @@ func loadConfig() (*config.Config, error) {
 		return nil, fmt.Errorf("unmarshaling config: %w", err)
 	}
 
+	// Resolve named clouds on top of the openstack section
+	clouds, err := loadClouds()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Clouds = clouds
+
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
@@ -180,6 +268,75 @@ func loadConfig() (*config.Config, error) {
 	return &cfg, nil
 }
 
+// loadClouds builds each clouds.<name> entry. An entry naming its own cloud
+// (cloud or auth_url) starts from the defaults plus the connection settings
+// of the openstack section; any other entry inherits the whole openstack
+// section, e.g. to reach another project or region of the same cloud. The
+// entry's clouds.yaml cloud and its own fields are layered on top.
+func loadClouds() (map[string]config.OpenStackConfig, error) {
+	entries := viper.GetStringMap("clouds")
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	base, _ := viper.AllSettings()["openstack"].(map[string]interface{})
+
+	clouds := make(map[string]config.OpenStackConfig, len(entries))
+	for name, raw := range entries {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("clouds.%s must be a mapping", name)
+		}
+
+		v := viper.New()
+		cloud, _ := entry["cloud"].(string)
+		_, hasAuthURL := entry["auth_url"]
+		if cloud != "" || hasAuthURL {
+			for key, value := range openStackDefaults {
+				v.SetDefault(key, value)
+			}
+			for _, key := range connectionSettings {
+				if value, ok := base[key]; ok {
+					v.SetDefault(key, value)
+				}
+			}
+		} else {
+			for key, value := range base {
+				v.SetDefault(key, value)
+			}
+			// A new project replaces both inherited project fields
+			_, hasProjectName := entry["project_name"]
+			_, hasProjectID := entry["project_id"]
+			if hasProjectName || hasProjectID {
+				v.SetDefault("project_name", "")
+				v.SetDefault("project_id", "")
+			}
+		}
+
+		if cloud != "" {
+			settings, err := config.LoadCloud(cloud)
+			if err != nil {
+				return nil, fmt.Errorf("loading cloud %q for clouds.%s: %w", cloud, name, err)
+			}
+			for key, value := range settings {
+				v.SetDefault(key, value)
+			}
+		}
+
+		for key, value := range entry {
+			v.Set(key, value)
+		}
+
+		var cfg config.OpenStackConfig
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, fmt.Errorf("unmarshaling clouds.%s: %w", name, err)
+		}
+		clouds[name] = cfg
+	}
+
+	return clouds, nil
+}
+
 // setupContext creates a context that cancels on SIGINT/SIGTERM
 func setupContext() context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
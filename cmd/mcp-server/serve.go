@@ -28,25 +28,57 @@ with MCP clients and provide access to OpenStack resources, tools, and prompts.`
 	cmd.Flags().String("transport", "stdio", "transport type (stdio, http)")
 	cmd.Flags().Int("port", 8080, "port for http transport")
 	cmd.Flags().String("host", "localhost", "host for http transport")
-	cmd.Flags().Duration("transport-timeout", 30*time.Second, "transport timeout")
+	cmd.Flags().Duration("transport-timeout", 30*time.Second, "time an HTTP client may take to send a request")
+	cmd.Flags().Bool("per-request-credentials", false, "use OpenStack credentials sent by each HTTP caller instead of the configured account")
+	cmd.Flags().String("http-auth", "none", "authentication of HTTP callers (none, static, jwt, keystone)")
+	cmd.Flags().String("http-auth-tokens-file", "", "file of accepted bearer tokens for static HTTP authentication")
+	cmd.Flags().String("http-auth-issuer", "", "OAuth authorization server issuing JWT access tokens")
+	cmd.Flags().String("http-auth-jwks-url", "", "JWKS URL of the authorization server; discovered from the issuer when empty")
+	cmd.Flags().String("http-auth-audience", "", "required JWT audience; defaults to the resource URL")
+	cmd.Flags().String("http-auth-resource-url", "", "public URL of the MCP endpoint, advertised to OAuth clients")
+	cmd.Flags().String("http-auth-roles-claim", "roles", "JWT claim holding the caller's roles, e.g. realm_access.roles")
+	cmd.Flags().String("tls-cert", "", "TLS certificate file for the http transport")
+	cmd.Flags().String("tls-key", "", "TLS private key file for the http transport")
+	cmd.Flags().String("tls-client-ca", "", "CA bundle for verifying client certificates (mutual TLS)")
 
 	// OpenStack auth flags (can override config file)
+	cmd.Flags().String("os-cloud", "", "named cloud to load from clouds.yaml")
 	cmd.Flags().String("os-auth-url", "", "OpenStack authentication URL")
+	cmd.Flags().String("os-auth-type", "", "OpenStack auth type (password, v3applicationcredential, token, v3totp, v3multifactor); detected when empty")
 	cmd.Flags().String("os-username", "", "OpenStack username")
+	cmd.Flags().String("os-user-id", "", "OpenStack user ID")
 	cmd.Flags().String("os-password", "", "OpenStack password")
+	cmd.Flags().String("os-passcode", "", "OpenStack TOTP passcode")
+	cmd.Flags().String("os-token", "", "pre-issued OpenStack token")
+	cmd.Flags().String("os-application-credential-id", "", "OpenStack application credential ID")
+	cmd.Flags().String("os-application-credential-name", "", "OpenStack application credential name")
+	cmd.Flags().String("os-application-credential-secret", "", "OpenStack application credential secret")
 	cmd.Flags().String("os-project-name", "", "OpenStack project name")
 	cmd.Flags().String("os-project-id", "", "OpenStack project ID")
 	cmd.Flags().String("os-region", "", "OpenStack region")
 	cmd.Flags().String("os-endpoint-type", "public", "OpenStack endpoint type (public, internal, admin)")
 	cmd.Flags().String("os-user-domain", "Default", "OpenStack user domain name")
 	cmd.Flags().String("os-project-domain", "Default", "OpenStack project domain name")
+	cmd.Flags().String("os-user-domain-id", "", "OpenStack user domain ID (overrides the name)")
+	cmd.Flags().String("os-project-domain-id", "", "OpenStack project domain ID (overrides the name)")
 	cmd.Flags().Bool("os-verify-ssl", true, "verify SSL certificates")
 	cmd.Flags().String("os-cacert", "", "path to CA certificate file")
+	cmd.Flags().String("os-cert", "", "path to client certificate file for mutual TLS")
+	cmd.Flags().String("os-key", "", "path to client private key file for mutual TLS")
 	cmd.Flags().Duration("os-timeout", 30*time.Second, "OpenStack API timeout")
 	cmd.Flags().Int("os-max-retries", 3, "maximum number of retries for OpenStack API calls")
 
 	// MCP server flags
 	cmd.Flags().Bool("read-only", false, "run in read-only mode (disable tools)")
+	cmd.Flags().Bool("dry-run", false, "make write tools return the OpenStack requests they would send without sending them")
+	cmd.Flags().Bool("audit", false, "record tool calls in the audit log")
+	cmd.Flags().String("audit-output", "stderr", "audit log output (stdout, stderr, syslog or a file path)")
+	cmd.Flags().Float64("audit-read-sample-rate", 0, "fraction of read-only tool calls recorded in the audit log (0 to 1)")
+	cmd.Flags().Bool("confirm-destructive", true, "ask the user to confirm calls of destructive tools")
+	cmd.Flags().String("confirmation-fallback", "argument", "confirmation for clients without elicitation (argument or reject)")
+	cmd.Flags().Duration("subscription-poll-interval", 30*time.Second, "interval at which subscribed resources are polled for changes")
+	cmd.Flags().Int("max-subscriptions-per-session", 100, "resource subscriptions a session may hold at once")
+	cmd.Flags().StringSlice("prompt-catalog", nil, "YAML prompt catalog adding or replacing runbook prompts (repeatable)")
 
 	// Bind flags to viper
 	flagBindings := map[string]string{
@@ -54,20 +86,54 @@ with MCP clients and provide access to OpenStack resources, tools, and prompts.`
 		"mcp.transport.port":            "port",
 		"mcp.transport.host":            "host",
 		"mcp.transport.timeout":         "transport-timeout",
+		"openstack.cloud":               "os-cloud",
 		"openstack.auth_url":            "os-auth-url",
+		"openstack.auth_type":           "os-auth-type",
 		"openstack.username":            "os-username",
+		"openstack.user_id":             "os-user-id",
 		"openstack.password":            "os-password",
+		"openstack.passcode":            "os-passcode",
+		"openstack.token":               "os-token",
 		"openstack.project_name":        "os-project-name",
 		"openstack.project_id":          "os-project-id",
 		"openstack.region":              "os-region",
 		"openstack.endpoint_type":       "os-endpoint-type",
 		"openstack.user_domain_name":    "os-user-domain",
 		"openstack.project_domain_name": "os-project-domain",
+		"openstack.user_domain_id":      "os-user-domain-id",
+		"openstack.project_domain_id":   "os-project-domain-id",
 		"openstack.verify_ssl":          "os-verify-ssl",
 		"openstack.ca_cert_file":        "os-cacert",
+		"openstack.client_cert_file":    "os-cert",
+		"openstack.client_key_file":     "os-key",
 		"openstack.timeout":             "os-timeout",
 		"openstack.max_retries":         "os-max-retries",
 		"mcp.read_only":                 "read-only",
+		"mcp.dry_run":                   "dry-run",
+
+		// Application credentials
+		"openstack.application_credential_id":     "os-application-credential-id",
+		"openstack.application_credential_name":   "os-application-credential-name",
+		"openstack.application_credential_secret": "os-application-credential-secret",
+		"mcp.transport.per_request_credentials":   "per-request-credentials",
+		"mcp.transport.auth.type":                 "http-auth",
+		"mcp.transport.auth.tokens_file":          "http-auth-tokens-file",
+		"mcp.transport.auth.issuer":               "http-auth-issuer",
+		"mcp.transport.auth.jwks_url":             "http-auth-jwks-url",
+		"mcp.transport.auth.audience":             "http-auth-audience",
+		"mcp.transport.auth.resource_url":         "http-auth-resource-url",
+		"mcp.transport.auth.roles_claim":          "http-auth-roles-claim",
+		"mcp.transport.tls_cert_file":             "tls-cert",
+		"mcp.transport.tls_key_file":              "tls-key",
+		"mcp.transport.tls_client_ca_file":        "tls-client-ca",
+		"mcp.audit.enabled":                       "audit",
+		"mcp.audit.output":                        "audit-output",
+		"mcp.audit.read_sample_rate":              "audit-read-sample-rate",
+		"mcp.confirmation.destructive":            "confirm-destructive",
+		"mcp.confirmation.fallback":               "confirmation-fallback",
+		"mcp.subscriptions.poll_interval":         "subscription-poll-interval",
+		"mcp.subscriptions.max_per_session":       "max-subscriptions-per-session",
+		"mcp.prompts.files":                       "prompt-catalog",
 	}
 	for key, flag := range flagBindings {
 		if err := viper.BindPFlag(key, cmd.Flags().Lookup(flag)); err != nil {
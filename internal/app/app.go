@@ -13,7 +13,7 @@ import (
 // App represents the application with all dependencies
 type App struct {
 	config    *config.Config
-	osClient  *o7k.Client
+	clients   *o7k.ClientSet
 	mcpServer *mcp.Server
 }
 
@@ -21,8 +21,8 @@ type App struct {
 func NewApp(cfg *config.Config) (*App, error) {
 	log.Info().Msg("Initializing application")
 
-	// Create OpenStack client
-	osClient, err := o7k.NewClient(&cfg.OpenStack)
+	// Create OpenStack clients; only the default cloud connects now
+	clients, err := o7k.NewClientSet(&cfg.OpenStack, cfg.Clouds, cfg.MCP.Transport.PerRequestCredentials)
 	if err != nil {
 		return nil, fmt.Errorf("creating OpenStack client: %w", err)
 	}
@@ -30,7 +30,7 @@ func NewApp(cfg *config.Config) (*App, error) {
 	log.Info().Msg("OpenStack client initialized successfully")
 
 	// Create MCP server
-	mcpServer, err := mcp.NewServer(&cfg.MCP, osClient)
+	mcpServer, err := mcp.NewServer(&cfg.MCP, clients)
 	if err != nil {
 		return nil, fmt.Errorf("creating MCP server: %w", err)
 	}
@@ -39,7 +39,7 @@ func NewApp(cfg *config.Config) (*App, error) {
 
 	return &App{
 		config:    cfg,
-		osClient:  osClient,
+		clients:   clients,
 		mcpServer: mcpServer,
 	}, nil
 }
@@ -68,9 +68,9 @@ func (a *App) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	// Close OpenStack client
-	if a.osClient != nil {
-		if err := a.osClient.Close(); err != nil {
+	// Close OpenStack clients
+	if a.clients != nil {
+		if err := a.clients.Close(); err != nil {
 			log.Error().Err(err).Msg("Error closing OpenStack client")
 			return fmt.Errorf("closing OpenStack client: %w", err)
 		}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported OpenStack authentication methods, named like clouds.yaml auth_type
+const (
+	AuthTypePassword              = "password"
+	AuthTypeApplicationCredential = "v3applicationcredential"
+	AuthTypeToken                 = "token"
+	AuthTypeTOTP                  = "v3totp"
+	AuthTypeMultiFactor           = "v3multifactor" // password + TOTP
+)
+
+// authTypeAliases maps alternative auth_type spellings to the canonical name
+var authTypeAliases = map[string]string{
+	AuthTypePassword:              AuthTypePassword,
+	"v3password":                  AuthTypePassword,
+	AuthTypeApplicationCredential: AuthTypeApplicationCredential,
+	"applicationcredential":       AuthTypeApplicationCredential,
+	AuthTypeToken:                 AuthTypeToken,
+	"v3token":                     AuthTypeToken,
+	AuthTypeTOTP:                  AuthTypeTOTP,
+	AuthTypeMultiFactor:           AuthTypeMultiFactor,
+}
+
+// ResolveAuthType returns the authentication method to use. An explicit
+// auth_type wins; otherwise the method is detected from the credentials and
+// exactly one complete method must be configured.
+func (c *OpenStackConfig) ResolveAuthType() (string, error) {
+	if c.AuthType != "" {
+		authType, ok := authTypeAliases[strings.ToLower(c.AuthType)]
+		if !ok {
+			return "", fmt.Errorf("unsupported auth type %q", c.AuthType)
+		}
+		return authType, nil
+	}
+
+	// Password plus passcode is a single multi-factor method, not two methods
+	candidates := []string{AuthTypeApplicationCredential, AuthTypeToken}
+	if len(c.missingAuthFields(AuthTypeMultiFactor)) == 0 {
+		candidates = append(candidates, AuthTypeMultiFactor)
+	} else {
+		candidates = append(candidates, AuthTypePassword, AuthTypeTOTP)
+	}
+
+	var complete []string
+	for _, authType := range candidates {
+		if len(c.missingAuthFields(authType)) == 0 {
+			complete = append(complete, authType)
+		}
+	}
+
+	switch len(complete) {
+	case 0:
+		return "", fmt.Errorf("no complete authentication method configured; provide username and password, an application credential, or a token")
+	case 1:
+		return complete[0], nil
+	default:
+		return "", fmt.Errorf("credentials for several authentication methods configured (%s); remove the unused ones or set auth_type", strings.Join(complete, ", "))
+	}
+}
+
+// missingAuthFields lists the settings an authentication method still needs
+func (c *OpenStackConfig) missingAuthFields(authType string) []string {
+	var missing []string
+	hasUser := c.Username != "" || c.UserID != ""
+
+	switch authType {
+	case AuthTypePassword:
+		if !hasUser {
+			missing = append(missing, "openstack.username")
+		}
+		if c.Password == "" {
+			missing = append(missing, "openstack.password")
+		}
+	case AuthTypeApplicationCredential:
+		if c.ApplicationCredentialSecret == "" {
+			missing = append(missing, "openstack.application_credential_secret")
+		}
+		if c.ApplicationCredentialID == "" {
+			if c.ApplicationCredentialName == "" {
+				missing = append(missing, "openstack.application_credential_id")
+			} else if !hasUser {
+				missing = append(missing, "openstack.username")
+			}
+		}
+	case AuthTypeToken:
+		if c.Token == "" {
+			missing = append(missing, "openstack.token")
+		}
+	case AuthTypeTOTP:
+		if !hasUser {
+			missing = append(missing, "openstack.username")
+		}
+		if c.Passcode == "" {
+			missing = append(missing, "openstack.passcode")
+		}
+	case AuthTypeMultiFactor:
+		if !hasUser {
+			missing = append(missing, "openstack.username")
+		}
+		if c.Password == "" {
+			missing = append(missing, "openstack.password")
+		}
+		if c.Passcode == "" {
+			missing = append(missing, "openstack.passcode")
+		}
+	}
+
+	return missing
+}
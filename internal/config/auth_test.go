@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestResolveAuthType checks the detection of the authentication method
+// from credentials, and the spellings auth_type accepts
+func TestResolveAuthType(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    OpenStackConfig
+		want      string
+		wantError string // Substring of the error expected, if any
+	}{
+		{"password", OpenStackConfig{Username: "admin", Password: "secret"}, AuthTypePassword, ""},
+		{"password with user ID", OpenStackConfig{UserID: "u1", Password: "secret"}, AuthTypePassword, ""},
+		{"application credential ID", OpenStackConfig{ApplicationCredentialID: "ac1", ApplicationCredentialSecret: "secret"}, AuthTypeApplicationCredential, ""},
+		{"application credential name and user", OpenStackConfig{ApplicationCredentialName: "ci", ApplicationCredentialSecret: "secret", Username: "admin"}, AuthTypeApplicationCredential, ""},
+		{"token", OpenStackConfig{Token: "gAAAA"}, AuthTypeToken, ""},
+		{"TOTP", OpenStackConfig{Username: "admin", Passcode: "123456"}, AuthTypeTOTP, ""},
+		{"password and passcode", OpenStackConfig{Username: "admin", Password: "secret", Passcode: "123456"}, AuthTypeMultiFactor, ""},
+
+		{"nothing", OpenStackConfig{}, "", "no complete authentication method"},
+		{"password without user", OpenStackConfig{Password: "secret"}, "", "no complete authentication method"},
+		{"application credential name without user", OpenStackConfig{ApplicationCredentialName: "ci", ApplicationCredentialSecret: "secret"}, "", "no complete authentication method"},
+		{"application credential without secret", OpenStackConfig{ApplicationCredentialID: "ac1"}, "", "no complete authentication method"},
+		{"password and token", OpenStackConfig{Username: "admin", Password: "secret", Token: "gAAAA"}, "", "several authentication methods configured (token, password)"},
+		{"application credential and password", OpenStackConfig{Username: "admin", Password: "secret", ApplicationCredentialName: "ci", ApplicationCredentialSecret: "secret"}, "", "several authentication methods configured (v3applicationcredential, password)"},
+		{"multi-factor and token", OpenStackConfig{Username: "admin", Password: "secret", Passcode: "123456", Token: "gAAAA"}, "", "several authentication methods configured (token, v3multifactor)"},
+
+		{"explicit wins", OpenStackConfig{AuthType: "token", Username: "admin", Password: "secret", Token: "gAAAA"}, AuthTypeToken, ""},
+		{"explicit without credentials", OpenStackConfig{AuthType: "password"}, AuthTypePassword, ""},
+		{"alias v3password", OpenStackConfig{AuthType: "v3password"}, AuthTypePassword, ""},
+		{"alias applicationcredential", OpenStackConfig{AuthType: "applicationcredential"}, AuthTypeApplicationCredential, ""},
+		{"alias v3token", OpenStackConfig{AuthType: "v3token"}, AuthTypeToken, ""},
+		{"alias in upper case", OpenStackConfig{AuthType: "V3ApplicationCredential"}, AuthTypeApplicationCredential, ""},
+		{"v3totp", OpenStackConfig{AuthType: "v3totp"}, AuthTypeTOTP, ""},
+		{"v3multifactor", OpenStackConfig{AuthType: "v3multifactor"}, AuthTypeMultiFactor, ""},
+		{"unsupported", OpenStackConfig{AuthType: "v3oidcpassword"}, "", `unsupported auth type "v3oidcpassword"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.ResolveAuthType()
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("ResolveAuthType() = %q, %v, want an error containing %q", got, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveAuthType() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveAuthType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
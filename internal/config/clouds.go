@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cloudEntry is the subset of a clouds.yaml cloud entry mapped onto OpenStackConfig
+type cloudEntry struct {
+	Auth struct {
+		AuthURL  string `yaml:"auth_url"`
+		Username string `yaml:"username"`
+		UserID   string `yaml:"user_id"`
+		Password string `yaml:"password"`
+		Passcode string `yaml:"passcode"`
+		Token    string `yaml:"token"`
+
+		ApplicationCredentialID     string `yaml:"application_credential_id"`
+		ApplicationCredentialName   string `yaml:"application_credential_name"`
+		ApplicationCredentialSecret string `yaml:"application_credential_secret"`
+
+		ProjectName       string `yaml:"project_name"`
+		ProjectID         string `yaml:"project_id"`
+		ProjectDomainName string `yaml:"project_domain_name"`
+		ProjectDomainID   string `yaml:"project_domain_id"`
+		UserDomainName    string `yaml:"user_domain_name"`
+		UserDomainID      string `yaml:"user_domain_id"`
+		DomainName        string `yaml:"domain_name"`
+		DomainID          string `yaml:"domain_id"`
+	} `yaml:"auth"`
+	AuthType     string `yaml:"auth_type"`
+	RegionName   string `yaml:"region_name"`
+	Interface    string `yaml:"interface"`
+	EndpointType string `yaml:"endpoint_type"`
+	Verify       *bool  `yaml:"verify"`
+	CACertFile   string `yaml:"cacert"`
+	CertFile     string `yaml:"cert"`
+	KeyFile      string `yaml:"key"`
+}
+
+// CloudsFileLocations returns the paths searched for clouds.yaml, in order.
+// OS_CLIENT_CONFIG_FILE replaces the standard locations, like openstackclient.
+func CloudsFileLocations() []string {
+	if path := os.Getenv("OS_CLIENT_CONFIG_FILE"); path != "" {
+		return []string{path}
+	}
+
+	var dirs []string
+	if cwd, err := os.Getwd(); err == nil {
+		dirs = append(dirs, cwd)
+	}
+	if userConfig, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(userConfig, "openstack"))
+	}
+	dirs = append(dirs, "/etc/openstack")
+
+	locations := make([]string, len(dirs))
+	for i, dir := range dirs {
+		locations[i] = filepath.Join(dir, "clouds.yaml")
+	}
+	return locations
+}
+
+// LoadCloud reads the named cloud from the first clouds.yaml found in the
+// standard locations, merged with the matching secure.yaml entry. The result
+// maps openstack.* config keys (without prefix) to the values the cloud sets,
+// so callers can layer them below explicit settings.
+func LoadCloud(name string) (map[string]interface{}, error) {
+	var cloudsPath string
+	for _, path := range CloudsFileLocations() {
+		if _, err := os.Stat(path); err == nil {
+			cloudsPath = path
+			break
+		}
+	}
+	if cloudsPath == "" {
+		return nil, fmt.Errorf("clouds.yaml not found, searched %v", CloudsFileLocations())
+	}
+
+	cloud, err := readCloud(cloudsPath, name)
+	if err != nil {
+		return nil, err
+	}
+	if cloud == nil {
+		return nil, fmt.Errorf("cloud %q not found in %s", name, cloudsPath)
+	}
+
+	// secure.yaml sits next to clouds.yaml and usually only holds secrets
+	securePath := filepath.Join(filepath.Dir(cloudsPath), "secure.yaml")
+	if _, err := os.Stat(securePath); err == nil {
+		secure, err := readCloud(securePath, name)
+		if err != nil {
+			return nil, err
+		}
+		if secure != nil {
+			mergeMaps(cloud, secure)
+		}
+	}
+
+	// Round-trip the merged entry through YAML to decode it into cloudEntry
+	raw, err := yaml.Marshal(cloud)
+	if err != nil {
+		return nil, fmt.Errorf("encoding cloud %q: %w", name, err)
+	}
+	var entry cloudEntry
+	if err := yaml.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("decoding cloud %q: %w", name, err)
+	}
+
+	return entry.settings(), nil
+}
+
+// readCloud returns the raw entry of the named cloud in a clouds/secure file,
+// or nil when the file has no such cloud
+func readCloud(path, name string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var file struct {
+		Clouds map[string]map[string]interface{} `yaml:"clouds"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return file.Clouds[name], nil
+}
+
+// mergeMaps recursively copies src into dst, src winning on conflicts
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// settings converts the entry into openstack.* config keys, skipping unset values
+func (e *cloudEntry) settings() map[string]interface{} {
+	settings := map[string]interface{}{}
+	set := func(key string, values ...string) {
+		for _, value := range values {
+			if value != "" {
+				settings[key] = value
+				return
+			}
+		}
+	}
+
+	set("auth_url", e.Auth.AuthURL)
+	set("auth_type", e.AuthType)
+	set("username", e.Auth.Username)
+	set("user_id", e.Auth.UserID)
+	set("password", e.Auth.Password)
+	set("passcode", e.Auth.Passcode)
+	set("token", e.Auth.Token)
+	set("application_credential_id", e.Auth.ApplicationCredentialID)
+	set("application_credential_name", e.Auth.ApplicationCredentialName)
+	set("application_credential_secret", e.Auth.ApplicationCredentialSecret)
+	set("project_name", e.Auth.ProjectName)
+	set("project_id", e.Auth.ProjectID)
+	set("project_domain_name", e.Auth.ProjectDomainName, e.Auth.DomainName)
+	set("project_domain_id", e.Auth.ProjectDomainID, e.Auth.DomainID)
+	set("user_domain_name", e.Auth.UserDomainName, e.Auth.DomainName)
+	set("user_domain_id", e.Auth.UserDomainID, e.Auth.DomainID)
+	set("region", e.RegionName)
+	set("endpoint_type", e.Interface, e.EndpointType)
+	set("ca_cert_file", e.CACertFile)
+	set("client_cert_file", e.CertFile)
+	set("client_key_file", e.KeyFile)
+	if e.Verify != nil {
+		settings["verify_ssl"] = *e.Verify
+	}
+
+	return settings
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFile creates a file and its parent directories
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("creating %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
+
+// cloudsFile returns a clouds.yaml holding one cloud with the given auth URL
+func cloudsFile(authURL string) string {
+	return "clouds:\n  test:\n    auth:\n      auth_url: " + authURL + "\n"
+}
+
+// setupCloudsDirs points the working directory and the user config
+// directory at empty temporary directories
+func setupCloudsDirs(t *testing.T) (cwd, userConfig string) {
+	t.Helper()
+	cwd = t.TempDir()
+	userConfig = t.TempDir()
+	t.Chdir(cwd)
+	t.Setenv("XDG_CONFIG_HOME", userConfig)
+	t.Setenv("OS_CLIENT_CONFIG_FILE", "")
+	return cwd, userConfig
+}
+
+// TestLoadCloudSearchOrder checks that the working directory comes before
+// the user config directory
+func TestLoadCloudSearchOrder(t *testing.T) {
+	cwd, userConfig := setupCloudsDirs(t)
+	writeFile(t, filepath.Join(userConfig, "openstack", "clouds.yaml"), cloudsFile("https://user.example.com"))
+
+	settings, err := LoadCloud("test")
+	if err != nil {
+		t.Fatalf("loading cloud: %v", err)
+	}
+	if settings["auth_url"] != "https://user.example.com" {
+		t.Errorf("auth_url = %v, want the user config file's", settings["auth_url"])
+	}
+
+	writeFile(t, filepath.Join(cwd, "clouds.yaml"), cloudsFile("https://cwd.example.com"))
+	settings, err = LoadCloud("test")
+	if err != nil {
+		t.Fatalf("loading cloud: %v", err)
+	}
+	if settings["auth_url"] != "https://cwd.example.com" {
+		t.Errorf("auth_url = %v, want the working directory file's", settings["auth_url"])
+	}
+}
+
+// TestLoadCloudClientConfigFile checks that OS_CLIENT_CONFIG_FILE replaces
+// the standard locations
+func TestLoadCloudClientConfigFile(t *testing.T) {
+	cwd, _ := setupCloudsDirs(t)
+	writeFile(t, filepath.Join(cwd, "clouds.yaml"), cloudsFile("https://cwd.example.com"))
+
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	writeFile(t, path, cloudsFile("https://custom.example.com"))
+	t.Setenv("OS_CLIENT_CONFIG_FILE", path)
+
+	if locations := CloudsFileLocations(); len(locations) != 1 || locations[0] != path {
+		t.Errorf("CloudsFileLocations() = %v, want [%s]", locations, path)
+	}
+	settings, err := LoadCloud("test")
+	if err != nil {
+		t.Fatalf("loading cloud: %v", err)
+	}
+	if settings["auth_url"] != "https://custom.example.com" {
+		t.Errorf("auth_url = %v, want the OS_CLIENT_CONFIG_FILE file's", settings["auth_url"])
+	}
+
+	t.Setenv("OS_CLIENT_CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+	if _, err := LoadCloud("test"); err == nil {
+		t.Error("LoadCloud() succeeded with a missing OS_CLIENT_CONFIG_FILE")
+	}
+}
+
+// TestLoadCloudSecureMerge checks that secure.yaml values are merged into
+// the cloud, winning on conflicts, and that domains map onto the config keys
+func TestLoadCloudSecureMerge(t *testing.T) {
+	cwd, _ := setupCloudsDirs(t)
+	writeFile(t, filepath.Join(cwd, "clouds.yaml"), `clouds:
+  test:
+    auth:
+      auth_url: https://keystone.example.com
+      username: admin
+      password: placeholder
+      project_name: demo
+      project_domain_id: 3c5e1b
+      user_domain_name: Users
+    region_name: RegionOne
+    interface: internal
+    verify: false
+  other:
+    auth:
+      auth_url: https://other.example.com
+`)
+	writeFile(t, filepath.Join(cwd, "secure.yaml"), `clouds:
+  test:
+    auth:
+      password: s3cret
+`)
+
+	settings, err := LoadCloud("test")
+	if err != nil {
+		t.Fatalf("loading cloud: %v", err)
+	}
+	want := map[string]interface{}{
+		"auth_url":          "https://keystone.example.com",
+		"username":          "admin",
+		"password":          "s3cret",
+		"project_name":      "demo",
+		"project_domain_id": "3c5e1b",
+		"user_domain_name":  "Users",
+		"region":            "RegionOne",
+		"endpoint_type":     "internal",
+		"verify_ssl":        false,
+	}
+	for key, value := range want {
+		if settings[key] != value {
+			t.Errorf("%s = %v, want %v", key, settings[key], value)
+		}
+	}
+	if len(settings) != len(want) {
+		t.Errorf("settings = %v, want only %v", settings, want)
+	}
+
+	// secure.yaml has no entry for this cloud
+	settings, err = LoadCloud("other")
+	if err != nil {
+		t.Fatalf("loading cloud: %v", err)
+	}
+	if _, ok := settings["password"]; ok {
+		t.Errorf("password = %v, want unset", settings["password"])
+	}
+
+	if _, err := LoadCloud("missing"); err == nil {
+		t.Error("LoadCloud() succeeded for a cloud not in clouds.yaml")
+	}
+}
+
+// TestLoadCloudDomain checks that domain_id and domain_name apply to both
+// the user and the project unless set for them
+func TestLoadCloudDomain(t *testing.T) {
+	cwd, _ := setupCloudsDirs(t)
+	writeFile(t, filepath.Join(cwd, "clouds.yaml"), `clouds:
+  test:
+    auth:
+      auth_url: https://keystone.example.com
+      domain_id: 9a7f
+      user_domain_id: 1b2c
+`)
+
+	settings, err := LoadCloud("test")
+	if err != nil {
+		t.Fatalf("loading cloud: %v", err)
+	}
+	if settings["project_domain_id"] != "9a7f" || settings["user_domain_id"] != "1b2c" {
+		t.Errorf("project_domain_id = %v, user_domain_id = %v, want 9a7f and 1b2c",
+			settings["project_domain_id"], settings["user_domain_id"])
+	}
+}
@@ -7,24 +7,49 @@ import (
 // Config represents the complete application configuration
 type Config struct {
 	OpenStack OpenStackConfig `mapstructure:"openstack"`
-	MCP       MCPConfig       `mapstructure:"mcp"`
-	Logging   LoggingConfig   `mapstructure:"logging"`
+
+	// Additional named connections, selectable per tool call with the
+	// "cloud" argument. Each entry inherits unset fields from OpenStack.
+	Clouds map[string]OpenStackConfig `mapstructure:"clouds"`
+
+	MCP     MCPConfig     `mapstructure:"mcp"`
+	Logging LoggingConfig `mapstructure:"logging"`
 }
 
+// DefaultCloudName names the connection configured by the top-level openstack section
+const DefaultCloudName = "default"
+
 // OpenStackConfig contains OpenStack authentication and connection settings
 type OpenStackConfig struct {
+	// Named cloud from clouds.yaml/secure.yaml; its values fill any field not
+	// set explicitly
+	Cloud string `mapstructure:"cloud"`
+
 	// Authentication
 	AuthURL  string `mapstructure:"auth_url"`
+	AuthType string `mapstructure:"auth_type"` // Optional, detected from the credentials when empty
 	Username string `mapstructure:"username"`
+	UserID   string `mapstructure:"user_id"`
 	Password string `mapstructure:"password"`
+	Passcode string `mapstructure:"passcode"` // TOTP passcode
+
+	// Application credential (ID, or name with username/user_id)
+	ApplicationCredentialID     string `mapstructure:"application_credential_id"`
+	ApplicationCredentialName   string `mapstructure:"application_credential_name"`
+	ApplicationCredentialSecret string `mapstructure:"application_credential_secret"`
+
+	// Pre-issued Keystone token
+	Token string `mapstructure:"token"`
 
 	// Project/Tenant scope
-	ProjectName   string `mapstructure:"project_name"`
-	ProjectID     string `mapstructure:"project_id"`
-	ProjectDomain string `mapstructure:"project_domain_name"`
+	ProjectName     string `mapstructure:"project_name"`
+	ProjectID       string `mapstructure:"project_id"`
+	ProjectDomain   string `mapstructure:"project_domain_name"`
+	ProjectDomainID string `mapstructure:"project_domain_id"` // Takes precedence over the name
 
 	// User domain
-	UserDomain string `mapstructure:"user_domain_name"`
+	UserDomain   string `mapstructure:"user_domain_name"`
+	UserDomainID string `mapstructure:"user_domain_id"` // Takes precedence over the name
 
 	// Region and endpoint settings
 	Region       string `mapstructure:"region"`
@@ -35,6 +60,10 @@ type OpenStackConfig struct {
 	MaxRetries int           `mapstructure:"max_retries"`
 	VerifySSL  bool          `mapstructure:"verify_ssl"`
 	CACertFile string        `mapstructure:"ca_cert_file"`
+
+	// Client certificate for mutual TLS
+	ClientCertFile string `mapstructure:"client_cert_file"`
+	ClientKeyFile  string `mapstructure:"client_key_file"`
 }
 
 // MCPConfig contains MCP server settings
@@ -48,6 +77,66 @@ type MCPConfig struct {
 
 	// Feature flag
 	ReadOnly bool `mapstructure:"read_only"`
+
+	// Write tools return the OpenStack requests they would send instead of
+	// sending them
+	DryRun bool `mapstructure:"dry_run"`
+
+	// Tool policy, applied on top of read-only mode
+	Policy PolicyConfig `mapstructure:"policy"`
+
+	// Tools each role of authenticated HTTP callers may see and call
+	Access AccessConfig `mapstructure:"access"`
+
+	// Audit log of tool calls
+	Audit AuditConfig `mapstructure:"audit"`
+
+	// Confirmation of destructive tool calls
+	Confirmation ConfirmationConfig `mapstructure:"confirmation"`
+
+	// Resource subscriptions
+	Subscriptions SubscriptionConfig `mapstructure:"subscriptions"`
+
+	// Runbook prompts
+	Prompts PromptsConfig `mapstructure:"prompts"`
+}
+
+// PromptsConfig extends the built-in runbook prompts
+type PromptsConfig struct {
+	// YAML prompt catalogs in the format of the built-in one; their prompts
+	// replace built-in prompts of the same name
+	Files []string `mapstructure:"files"`
+}
+
+// SubscriptionConfig controls resource subscriptions. Subscribed resources
+// are polled, once per interval however many sessions subscribed to them.
+type SubscriptionConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// Subscriptions a session may hold at once
+	MaxPerSession int `mapstructure:"max_per_session"`
+}
+
+// Confirmation fallbacks for clients without elicitation support
+const (
+	ConfirmationFallbackArgument = "argument" // Require the resource ID in the confirm argument
+	ConfirmationFallbackReject   = "reject"   // Reject the call
+)
+
+// ConfirmationConfig controls how calls of destructive tools are confirmed.
+// The user is asked through MCP elicitation; the fallback applies to
+// clients that do not support it.
+type ConfirmationConfig struct {
+	Destructive bool   `mapstructure:"destructive"` // Confirm destructive tools, in addition to policy.require_confirmation
+	Fallback    string `mapstructure:"fallback"`    // "argument" or "reject"
+}
+
+// AuditConfig controls the audit log of tool calls. Calls of write tools
+// are always recorded, calls of read-only tools at the sample rate.
+type AuditConfig struct {
+	Enabled        bool    `mapstructure:"enabled"`
+	Output         string  `mapstructure:"output"`           // "stdout", "stderr", "syslog" or a file path
+	ReadSampleRate float64 `mapstructure:"read_sample_rate"` // Fraction of read-only calls recorded, 0 to 1
 }
 
 // TransportConfig defines how MCP communicates
@@ -58,8 +147,43 @@ type TransportConfig struct {
 	Port int    `mapstructure:"port"`
 	Host string `mapstructure:"host"`
 
-	// Connection timeout
+	// Time an HTTP client may take to send a request, headers and body
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Use OpenStack credentials sent by each caller (X-Auth-Token or
+	// application credential headers) instead of the configured account
+	PerRequestCredentials bool `mapstructure:"per_request_credentials"`
+
+	// Authentication of HTTP callers
+	Auth HTTPAuthConfig `mapstructure:"auth"`
+
+	// TLS for the HTTP transport; the files are reloaded when they change
+	TLSCertFile     string `mapstructure:"tls_cert_file"`
+	TLSKeyFile      string `mapstructure:"tls_key_file"`
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file"` // Requires client certificates (mTLS)
+}
+
+// Supported authentication methods of HTTP callers
+const (
+	HTTPAuthNone     = "none"
+	HTTPAuthStatic   = "static"   // Bearer tokens listed in a file
+	HTTPAuthJWT      = "jwt"      // OAuth 2.1 access tokens (JWT)
+	HTTPAuthKeystone = "keystone" // Keystone tokens
+)
+
+// HTTPAuthConfig controls how callers of the HTTP transport authenticate
+type HTTPAuthConfig struct {
+	Type string `mapstructure:"type"` // "none", "static", "jwt" or "keystone"
+
+	// static: file with one accepted bearer token per line, e.g. a mounted secret
+	TokensFile string `mapstructure:"tokens_file"`
+
+	// jwt: OAuth 2.1 access tokens of an authorization server
+	Issuer      string `mapstructure:"issuer"`
+	JWKSURL     string `mapstructure:"jwks_url"`     // Discovered from the issuer when empty
+	Audience    string `mapstructure:"audience"`     // Defaults to ResourceURL
+	ResourceURL string `mapstructure:"resource_url"` // Public URL of the MCP endpoint
+	RolesClaim  string `mapstructure:"roles_claim"`  // Claim holding the caller's roles, e.g. realm_access.roles
 }
 
 // LoggingConfig controls application logging
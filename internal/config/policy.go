@@ -0,0 +1,193 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PolicyConfig restricts which tools are exposed and how they may be called
+type PolicyConfig struct {
+	// Tool names or globs ("volume_*"). An empty allow list allows every
+	// tool; deny wins over allow.
+	Allow []string `mapstructure:"allow"`
+	Deny  []string `mapstructure:"deny"`
+
+	// Argument constraints such as "volume_create.size <= 500" or
+	// "share_create.share_proto in [NFS, CEPHFS]"
+	Constraints []string `mapstructure:"constraints"`
+
+	// Tools (names or globs) that must be confirmed before they run
+	RequireConfirmation []string `mapstructure:"require_confirmation"`
+
+	// Sensitive tools (names or globs) to expose. Tools handing out
+	// credentials, such as coe_cluster_kubeconfig, are left out unless
+	// listed here.
+	AllowSensitive []string `mapstructure:"allow_sensitive"`
+}
+
+// AccessConfig maps the roles of authenticated HTTP callers to tools.
+// Without roles, every caller may use every tool the policy exposes.
+type AccessConfig struct {
+	// Role name to tool names or globs; AccessReadOnlyTools selects every
+	// read-only tool. A caller gets the tools of all its roles.
+	Roles map[string][]string `mapstructure:"roles"`
+
+	// Tools of callers without any mapped role
+	DefaultTools []string `mapstructure:"default_tools"`
+}
+
+// AccessReadOnlyTools stands for every read-only tool in a role's tool list
+const AccessReadOnlyTools = "@read"
+
+// Constraint operators
+const (
+	OperatorLess         = "<"
+	OperatorLessEqual    = "<="
+	OperatorGreater      = ">"
+	OperatorGreaterEqual = ">="
+	OperatorEqual        = "=="
+	OperatorNotEqual     = "!="
+	OperatorIn           = "in"
+	OperatorNotIn        = "not in"
+)
+
+// ToolConstraint limits the value of one argument of the matching tools
+type ToolConstraint struct {
+	Tool     string // Tool name or glob
+	Argument string
+	Operator string
+	Values   []string // One value, or the list of in/not in
+}
+
+// constraintPattern matches "<tool>.<argument> <operator> <value>"
+var constraintPattern = regexp.MustCompile(`^\s*([\w*?\[\]-]+)\.(\w+)\s*(<=|>=|==|!=|<|>|\s+not\s+in\s+|\s+in\s+)\s*(.+?)\s*$`)
+
+// ParseToolConstraint parses a constraint such as "volume_create.size <= 500"
+func ParseToolConstraint(s string) (ToolConstraint, error) {
+	m := constraintPattern.FindStringSubmatch(s)
+	if m == nil {
+		return ToolConstraint{}, fmt.Errorf("invalid constraint %q, expected '<tool>.<argument> <operator> <value>'", s)
+	}
+	if _, err := path.Match(m[1], ""); err != nil {
+		return ToolConstraint{}, fmt.Errorf("invalid tool pattern in constraint %q: %w", s, err)
+	}
+
+	c := ToolConstraint{
+		Tool:     m[1],
+		Argument: m[2],
+		Operator: strings.Join(strings.Fields(m[3]), " "),
+	}
+
+	value := m[4]
+	switch c.Operator {
+	case OperatorIn, OperatorNotIn:
+		if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+			return ToolConstraint{}, fmt.Errorf("invalid constraint %q, %s expects a list like [a, b]", s, c.Operator)
+		}
+		for _, item := range strings.Split(strings.Trim(value, "[]"), ",") {
+			if item = unquote(strings.TrimSpace(item)); item != "" {
+				c.Values = append(c.Values, item)
+			}
+		}
+		if len(c.Values) == 0 {
+			return ToolConstraint{}, fmt.Errorf("invalid constraint %q, empty list", s)
+		}
+	case OperatorEqual, OperatorNotEqual:
+		c.Values = []string{unquote(value)}
+	default:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return ToolConstraint{}, fmt.Errorf("invalid constraint %q, %s expects a number", s, c.Operator)
+		}
+		c.Values = []string{value}
+	}
+
+	return c, nil
+}
+
+// RequiresArgument reports whether the constraint rejects calls leaving out
+// its argument. Only exclusions (!= and not in) hold for an absent argument;
+// the other operators name the values the argument must have.
+func (c ToolConstraint) RequiresArgument() bool {
+	return c.Operator != OperatorNotEqual && c.Operator != OperatorNotIn
+}
+
+// Satisfied reports whether an argument value meets the constraint. Number
+// values equal constraint values of the same number in any notation, e.g.
+// 1000000 and 1e6; strings are compared as given. Numeric operators reject
+// values that are not numbers.
+func (c ToolConstraint) Satisfied(value interface{}) bool {
+	text := FormatValue(value)
+	_, number := value.(float64)
+	switch c.Operator {
+	case OperatorEqual:
+		return equal(text, c.Values[0], number)
+	case OperatorNotEqual:
+		return !equal(text, c.Values[0], number)
+	case OperatorIn:
+		return contains(c.Values, text, number)
+	case OperatorNotIn:
+		return !contains(c.Values, text, number)
+	}
+
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return false
+	}
+	limit, _ := strconv.ParseFloat(c.Values[0], 64)
+	switch c.Operator {
+	case OperatorLess:
+		return n < limit
+	case OperatorLessEqual:
+		return n <= limit
+	case OperatorGreater:
+		return n > limit
+	case OperatorGreaterEqual:
+		return n >= limit
+	}
+	return false
+}
+
+// FormatValue renders an argument value as constraints compare it; JSON
+// numbers are float64 and are written without exponent, so that 1000000
+// does not read as "1e+06"
+func FormatValue(value interface{}) string {
+	if v, ok := value.(float64); ok {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// equal reports whether a value equals a constraint value, as text or, for
+// number values, numerically
+func equal(value, want string, number bool) bool {
+	if value == want {
+		return true
+	}
+	if !number {
+		return false
+	}
+	a, errA := strconv.ParseFloat(value, 64)
+	b, errB := strconv.ParseFloat(want, 64)
+	return errA == nil && errB == nil && a == b
+}
+
+// contains reports whether values holds s
+func contains(values []string, s string, number bool) bool {
+	for _, v := range values {
+		if equal(s, v, number) {
+			return true
+		}
+	}
+	return false
+}
+
+// unquote strips matching single or double quotes
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestParseToolConstraint checks the constraint syntax and its errors
+func TestParseToolConstraint(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ToolConstraint
+		wantErr bool
+	}{
+		{in: "volume_create.size <= 500", want: ToolConstraint{"volume_create", "size", OperatorLessEqual, []string{"500"}}},
+		{in: "  volume_create.size>1e3 ", want: ToolConstraint{"volume_create", "size", OperatorGreater, []string{"1e3"}}},
+		{in: "quota_update_*.project_id == '0d5e2f7c'", want: ToolConstraint{"quota_update_*", "project_id", OperatorEqual, []string{"0d5e2f7c"}}},
+		{in: `share_access_grant.access_level != "rw"`, want: ToolConstraint{"share_access_grant", "access_level", OperatorNotEqual, []string{"rw"}}},
+		{in: "share_create.share_proto in [NFS, 'CEPHFS', ]", want: ToolConstraint{"share_create", "share_proto", OperatorIn, []string{"NFS", "CEPHFS"}}},
+		{in: "share_create.share_proto  not  in [CIFS]", want: ToolConstraint{"share_create", "share_proto", OperatorNotIn, []string{"CIFS"}}},
+		{in: "volume_create.size <= large", wantErr: true},
+		{in: "share_create.share_proto in NFS", wantErr: true},
+		{in: "share_create.share_proto in [ , ]", wantErr: true},
+		{in: "volume_[create.size <= 500", wantErr: true},
+		{in: "volume_create size <= 500", wantErr: true},
+		{in: "volume_create.size", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseToolConstraint(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseToolConstraint(%q) error = %v, want error: %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got.Tool != tt.want.Tool || got.Argument != tt.want.Argument ||
+			got.Operator != tt.want.Operator || !slices.Equal(got.Values, tt.want.Values) {
+			t.Errorf("ParseToolConstraint(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestSatisfied checks each operator against string and JSON number values
+func TestSatisfied(t *testing.T) {
+	tests := []struct {
+		constraint string
+		value      interface{}
+		want       bool
+	}{
+		{"volume_create.size <= 500", 500.0, true},
+		{"volume_create.size <= 500", 500.5, false},
+		{"volume_create.size < 500", 499.0, true},
+		{"volume_create.size > 0", 0.0, false},
+		{"volume_create.size >= 1e3", 1000.0, true},
+		{"volume_create.size <= 500", "100", true},
+		{"volume_create.size <= 500", "large", false},
+		{"volume_create.size <= 500", true, false},
+		{"quota_update_compute.cores == 1000000", 1e6, true},
+		{"quota_update_compute.cores == 1e6", 1e6, true},
+		{"quota_update_compute.cores != 1000000", 1e6, false},
+		{"quota_update_compute.cores in [10, 20]", 20.0, true},
+		{"quota_update_compute.cores not in [10, 20]", 1e7, true},
+		{"share_access_grant.access_level == ro", "ro", true},
+		{"share_access_grant.access_level == ro", "RO", false},
+		{"quota_update_compute.project_id == 0100", "100", false},
+		{"share_create.share_proto in [NFS, CEPHFS]", "CEPHFS", true},
+		{"share_create.share_proto not in [NFS, CEPHFS]", "CIFS", true},
+		{"share_create.share_proto not in [NFS, CEPHFS]", "NFS", false},
+		{"volume_create.bootable == true", true, true},
+	}
+	for _, tt := range tests {
+		c, err := ParseToolConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseToolConstraint(%q): %v", tt.constraint, err)
+		}
+		if got := c.Satisfied(tt.value); got != tt.want {
+			t.Errorf("%s satisfied by %#v = %t, want %t", tt.constraint, tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestFormatValue checks that JSON numbers render without exponent
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{1e6, "1000000"},
+		{1.5, "1.5"},
+		{1e21, "1000000000000000000000"},
+		{"1e6", "1e6"},
+		{true, "true"},
+	}
+	for _, tt := range tests {
+		if got := FormatValue(tt.value); got != tt.want {
+			t.Errorf("FormatValue(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
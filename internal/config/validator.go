@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path"
+	"sort"
 	"strings"
+	"time"
 )
 
 // ValidationError represents a configuration validation error
@@ -33,7 +36,8 @@ func (c *Config) Validate() error {
 	var errors ValidationErrors
 
 	// Validate OpenStack configuration
-	errors = append(errors, c.validateOpenStack()...)
+	errors = append(errors, validateOpenStack(&c.OpenStack, !c.MCP.Transport.PerRequestCredentials)...)
+	errors = append(errors, c.validateClouds()...)
 
 	// Validate MCP configuration
 	errors = append(errors, c.validateMCP()...)
@@ -48,18 +52,18 @@ func (c *Config) Validate() error {
 }
 
 // validateOpenStack validates OpenStack-specific configuration
-func (c *Config) validateOpenStack() []ValidationError {
+func validateOpenStack(cfg *OpenStackConfig, requireAuth bool) []ValidationError {
 	var errors []ValidationError
 
 	// Required fields
-	if c.OpenStack.AuthURL == "" {
+	if cfg.AuthURL == "" {
 		errors = append(errors, ValidationError{
 			Field:   "openstack.auth_url",
 			Message: "authentication URL is required",
 		})
 	} else {
 		// Validate URL format
-		if _, err := url.Parse(c.OpenStack.AuthURL); err != nil {
+		if _, err := url.Parse(cfg.AuthURL); err != nil {
 			errors = append(errors, ValidationError{
 				Field:   "openstack.auth_url",
 				Message: fmt.Sprintf("invalid URL format: %v", err),
@@ -67,31 +71,57 @@ func (c *Config) validateOpenStack() []ValidationError {
 		}
 	}
 
-	if c.OpenStack.Username == "" {
-		errors = append(errors, ValidationError{
-			Field:   "openstack.username",
-			Message: "username is required",
-		})
-	}
+	// Callers bring their own credentials in per-request credentials mode
+	if requireAuth {
+		// Exactly one complete authentication method
+		authType, err := cfg.ResolveAuthType()
+		if err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "openstack.auth_type",
+				Message: err.Error(),
+			})
+		} else {
+			for _, field := range cfg.missingAuthFields(authType) {
+				errors = append(errors, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("required for %s authentication", authType),
+				})
+			}
+		}
 
-	if c.OpenStack.Password == "" {
-		errors = append(errors, ValidationError{
-			Field:   "openstack.password",
-			Message: "password is required",
-		})
-	}
+		if cfg.Username != "" && cfg.UserID != "" {
+			errors = append(errors, ValidationError{
+				Field:   "openstack.user_id",
+				Message: "username and user_id are mutually exclusive",
+			})
+		}
 
-	// Project scope validation (need at least one)
-	if c.OpenStack.ProjectName == "" && c.OpenStack.ProjectID == "" {
-		errors = append(errors, ValidationError{
-			Field:   "openstack.project",
-			Message: "either project_name or project_id is required",
-		})
+		hasProject := cfg.ProjectName != "" || cfg.ProjectID != ""
+		switch authType {
+		case AuthTypeApplicationCredential:
+			// Application credentials are bound to the project they were created in
+			if hasProject {
+				errors = append(errors, ValidationError{
+					Field:   "openstack.project",
+					Message: "project_name and project_id must not be set with application credentials",
+				})
+			}
+		case AuthTypeToken:
+			// Project scope is optional: the token is used as is when unset
+		default:
+			// Project scope validation (need at least one)
+			if !hasProject {
+				errors = append(errors, ValidationError{
+					Field:   "openstack.project",
+					Message: "either project_name or project_id is required",
+				})
+			}
+		}
 	}
 
 	// Validate endpoint type
 	validEndpoints := map[string]bool{"public": true, "internal": true, "admin": true}
-	if c.OpenStack.EndpointType != "" && !validEndpoints[c.OpenStack.EndpointType] {
+	if cfg.EndpointType != "" && !validEndpoints[cfg.EndpointType] {
 		errors = append(errors, ValidationError{
 			Field:   "openstack.endpoint_type",
 			Message: "must be one of: public, internal, admin",
@@ -99,24 +129,46 @@ func (c *Config) validateOpenStack() []ValidationError {
 	}
 
 	// Validate CA cert file if specified
-	if c.OpenStack.CACertFile != "" {
-		if _, err := os.Stat(c.OpenStack.CACertFile); os.IsNotExist(err) {
+	if cfg.CACertFile != "" {
+		if _, err := os.Stat(cfg.CACertFile); os.IsNotExist(err) {
 			errors = append(errors, ValidationError{
 				Field:   "openstack.ca_cert_file",
-				Message: fmt.Sprintf("file does not exist: %s", c.OpenStack.CACertFile),
+				Message: fmt.Sprintf("file does not exist: %s", cfg.CACertFile),
+			})
+		}
+	}
+
+	// Client certificate and key go together
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		errors = append(errors, ValidationError{
+			Field:   "openstack.client_cert_file",
+			Message: "client_cert_file and client_key_file must be set together",
+		})
+	}
+	for field, path := range map[string]string{
+		"openstack.client_cert_file": cfg.ClientCertFile,
+		"openstack.client_key_file":  cfg.ClientKeyFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("file does not exist: %s", path),
 			})
 		}
 	}
 
 	// Validate timeout and retries
-	if c.OpenStack.Timeout <= 0 {
+	if cfg.Timeout <= 0 {
 		errors = append(errors, ValidationError{
 			Field:   "openstack.timeout",
 			Message: "must be greater than 0",
 		})
 	}
 
-	if c.OpenStack.MaxRetries < 0 {
+	if cfg.MaxRetries < 0 {
 		errors = append(errors, ValidationError{
 			Field:   "openstack.max_retries",
 			Message: "must be non-negative",
@@ -126,6 +178,36 @@ func (c *Config) validateOpenStack() []ValidationError {
 	return errors
 }
 
+// validateClouds validates the additional named connections, reporting
+// fields under clouds.<name> instead of openstack
+func (c *Config) validateClouds() []ValidationError {
+	var errors []ValidationError
+
+	names := make([]string, 0, len(c.Clouds))
+	for name := range c.Clouds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == DefaultCloudName {
+			errors = append(errors, ValidationError{
+				Field:   "clouds." + name,
+				Message: fmt.Sprintf("%q is reserved for the openstack section", DefaultCloudName),
+			})
+			continue
+		}
+
+		cloud := c.Clouds[name]
+		for _, err := range validateOpenStack(&cloud, !c.MCP.Transport.PerRequestCredentials) {
+			err.Field = "clouds." + name + strings.TrimPrefix(err.Field, "openstack")
+			errors = append(errors, err)
+		}
+	}
+
+	return errors
+}
+
 // validateMCP validates MCP-specific configuration
 func (c *Config) validateMCP() []ValidationError {
 	var errors []ValidationError
@@ -139,6 +221,34 @@ func (c *Config) validateMCP() []ValidationError {
 		})
 	}
 
+	// Per-request credentials come from HTTP headers
+	if c.MCP.Transport.PerRequestCredentials && c.MCP.Transport.Type != "http" {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.transport.per_request_credentials",
+			Message: "requires http transport",
+		})
+	}
+
+	errors = append(errors, c.validateHTTPAuth()...)
+	errors = append(errors, c.validateTLS()...)
+	errors = append(errors, c.validatePolicy()...)
+	errors = append(errors, c.validateAudit()...)
+	errors = append(errors, c.validateConfirmation()...)
+
+	// Polling more often would load the OpenStack APIs for little benefit
+	if c.MCP.Subscriptions.PollInterval < time.Second {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.subscriptions.poll_interval",
+			Message: "must be at least 1s",
+		})
+	}
+	if c.MCP.Subscriptions.MaxPerSession < 1 {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.subscriptions.max_per_session",
+			Message: "must be at least 1",
+		})
+	}
+
 	// HTTP-specific validation
 	if c.MCP.Transport.Type == "http" {
 		if c.MCP.Transport.Port <= 0 || c.MCP.Transport.Port > 65535 {
@@ -187,3 +297,227 @@ func (c *Config) validateLogging() []ValidationError {
 
 	return errors
 }
+
+// validatePolicy validates the tool patterns and argument constraints of the
+// tool policy and the tool patterns of the role mapping
+func (c *Config) validatePolicy() []ValidationError {
+	var errors []ValidationError
+	policy := c.MCP.Policy
+
+	patterns := []struct {
+		field    string
+		patterns []string
+	}{
+		{"mcp.policy.allow", policy.Allow},
+		{"mcp.policy.deny", policy.Deny},
+		{"mcp.policy.require_confirmation", policy.RequireConfirmation},
+		{"mcp.policy.allow_sensitive", policy.AllowSensitive},
+	}
+	for _, p := range patterns {
+		for _, pattern := range p.patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errors = append(errors, ValidationError{
+					Field:   p.field,
+					Message: fmt.Sprintf("invalid pattern %q: %v", pattern, err),
+				})
+			}
+		}
+	}
+
+	roles := make([]string, 0, len(c.MCP.Access.Roles))
+	for role := range c.MCP.Access.Roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		for _, pattern := range c.MCP.Access.Roles[role] {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errors = append(errors, ValidationError{
+					Field:   "mcp.access.roles." + role,
+					Message: fmt.Sprintf("invalid pattern %q: %v", pattern, err),
+				})
+			}
+		}
+	}
+
+	// Roles come from the identity of authenticated HTTP callers
+	if len(c.MCP.Access.Roles) > 0 {
+		if auth := c.MCP.Transport.Auth.Type; auth == "" || auth == HTTPAuthNone {
+			errors = append(errors, ValidationError{
+				Field:   "mcp.access.roles",
+				Message: "requires http transport with authentication (mcp.transport.auth.type)",
+			})
+		}
+	}
+
+	for _, constraint := range policy.Constraints {
+		if _, err := ParseToolConstraint(constraint); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "mcp.policy.constraints",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateAudit validates the audit log settings
+func (c *Config) validateAudit() []ValidationError {
+	var errors []ValidationError
+	audit := c.MCP.Audit
+
+	if !audit.Enabled {
+		return nil
+	}
+
+	if audit.Output == "" {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.audit.output",
+			Message: "is required when the audit log is enabled",
+		})
+	}
+
+	// stdout carries the protocol messages of the stdio transport
+	if audit.Output == "stdout" && c.MCP.Transport.Type == "stdio" {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.audit.output",
+			Message: "stdout is reserved for the stdio transport, use stderr, syslog or a file",
+		})
+	}
+
+	if audit.ReadSampleRate < 0 || audit.ReadSampleRate > 1 {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.audit.read_sample_rate",
+			Message: "must be between 0 and 1",
+		})
+	}
+
+	return errors
+}
+
+// validateConfirmation validates the confirmation settings
+func (c *Config) validateConfirmation() []ValidationError {
+	switch c.MCP.Confirmation.Fallback {
+	case ConfirmationFallbackArgument, ConfirmationFallbackReject:
+		return nil
+	}
+	return []ValidationError{{
+		Field:   "mcp.confirmation.fallback",
+		Message: fmt.Sprintf("must be %q or %q", ConfirmationFallbackArgument, ConfirmationFallbackReject),
+	}}
+}
+
+// validateTLS validates the TLS settings of the HTTP transport
+func (c *Config) validateTLS() []ValidationError {
+	var errors []ValidationError
+	transport := c.MCP.Transport
+
+	if transport.TLSCertFile == "" && transport.TLSKeyFile == "" && transport.TLSClientCAFile == "" {
+		return nil
+	}
+
+	if transport.Type != "http" {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.transport.tls_cert_file",
+			Message: "requires http transport",
+		})
+	}
+
+	if transport.TLSCertFile == "" || transport.TLSKeyFile == "" {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.transport.tls_cert_file",
+			Message: "tls_cert_file and tls_key_file must be set together",
+		})
+	}
+
+	files := []struct{ field, path string }{
+		{"mcp.transport.tls_cert_file", transport.TLSCertFile},
+		{"mcp.transport.tls_key_file", transport.TLSKeyFile},
+		{"mcp.transport.tls_client_ca_file", transport.TLSClientCAFile},
+	}
+	for _, f := range files {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); os.IsNotExist(err) {
+			errors = append(errors, ValidationError{
+				Field:   f.field,
+				Message: fmt.Sprintf("file does not exist: %s", f.path),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateHTTPAuth validates the authentication of HTTP callers
+func (c *Config) validateHTTPAuth() []ValidationError {
+	var errors []ValidationError
+	auth := c.MCP.Transport.Auth
+
+	switch auth.Type {
+	case "", HTTPAuthNone:
+		return nil
+	case HTTPAuthStatic, HTTPAuthJWT, HTTPAuthKeystone:
+	default:
+		return []ValidationError{{
+			Field:   "mcp.transport.auth.type",
+			Message: "must be one of 'none', 'static', 'jwt' or 'keystone'",
+		}}
+	}
+
+	if c.MCP.Transport.Type != "http" {
+		errors = append(errors, ValidationError{
+			Field:   "mcp.transport.auth.type",
+			Message: "requires http transport",
+		})
+	}
+
+	switch auth.Type {
+	case HTTPAuthStatic:
+		if auth.TokensFile == "" {
+			errors = append(errors, ValidationError{
+				Field:   "mcp.transport.auth.tokens_file",
+				Message: "is required for static authentication",
+			})
+		} else if _, err := os.Stat(auth.TokensFile); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "mcp.transport.auth.tokens_file",
+				Message: fmt.Sprintf("cannot read file: %v", err),
+			})
+		}
+	case HTTPAuthJWT:
+		if auth.Issuer == "" {
+			errors = append(errors, ValidationError{
+				Field:   "mcp.transport.auth.issuer",
+				Message: "is required for jwt authentication",
+			})
+		}
+		// Tokens minted for other resources must not be accepted
+		if auth.Audience == "" && auth.ResourceURL == "" {
+			errors = append(errors, ValidationError{
+				Field:   "mcp.transport.auth.audience",
+				Message: "audience or resource_url is required for jwt authentication",
+			})
+		}
+		urls := []struct{ field, value string }{
+			{"mcp.transport.auth.issuer", auth.Issuer},
+			{"mcp.transport.auth.jwks_url", auth.JWKSURL},
+			{"mcp.transport.auth.resource_url", auth.ResourceURL},
+		}
+		for _, u := range urls {
+			if u.value == "" {
+				continue
+			}
+			if parsed, err := url.Parse(u.value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				errors = append(errors, ValidationError{
+					Field:   u.field,
+					Message: "must be an absolute URL",
+				})
+			}
+		}
+	}
+
+	return errors
+}
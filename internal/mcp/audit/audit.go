@@ -0,0 +1,157 @@
+// Package audit records tool calls as JSON lines
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/rs/zerolog/log"
+)
+
+// maxArgumentLength truncates long string arguments in records
+const maxArgumentLength = 256
+
+// sensitiveArguments are argument name fragments whose values are redacted,
+// matched ignoring case, hyphens and underscores
+var sensitiveArguments = []string{"password", "passcode", "secret", "token", "privatekey"}
+
+// Record is one audited tool call
+type Record struct {
+	Time       time.Time              `json:"time"`
+	Tool       string                 `json:"tool"`
+	ReadOnly   bool                   `json:"read_only"`
+	DryRun     bool                   `json:"dry_run,omitempty"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	Resolved   map[string]string      `json:"resolved_ids,omitempty"` // IDs the names given in arguments resolved to
+	Caller     *auth.Identity         `json:"caller,omitempty"`
+	SessionID  string                 `json:"session_id,omitempty"`
+	RequestIDs []string               `json:"openstack_request_ids,omitempty"`
+	Status     string                 `json:"status"` // "success" or "error"
+	Error      string                 `json:"error,omitempty"`
+	DurationMS int64                  `json:"duration_ms"`
+}
+
+// Logger writes audit records to the configured output
+type Logger struct {
+	mu             sync.Mutex
+	out            io.Writer
+	closer         io.Closer
+	readSampleRate float64
+}
+
+// New opens the configured audit output
+func New(cfg *config.AuditConfig) (*Logger, error) {
+	l := &Logger{readSampleRate: cfg.ReadSampleRate}
+
+	switch cfg.Output {
+	case "stdout":
+		l.out = os.Stdout
+	case "stderr":
+		l.out = os.Stderr
+	case "syslog":
+		w, err := syslog.New(syslog.LOG_NOTICE|syslog.LOG_AUTH, "openstack-mcp-server")
+		if err != nil {
+			return nil, fmt.Errorf("connecting to syslog: %w", err)
+		}
+		l.out, l.closer = w, w
+	default:
+		f, err := os.OpenFile(cfg.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("opening audit log: %w", err)
+		}
+		l.out, l.closer = f, f
+	}
+
+	log.Info().
+		Str("output", cfg.Output).
+		Float64("read_sample_rate", cfg.ReadSampleRate).
+		Msg("Audit log enabled")
+	return l, nil
+}
+
+// Sampled reports whether a call should be recorded: write tools always,
+// read-only tools at the configured sample rate
+func (l *Logger) Sampled(readOnly bool) bool {
+	if !readOnly || l.readSampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < l.readSampleRate
+}
+
+// Log writes a record as one JSON line
+func (l *Logger) Log(record Record) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Error().Err(err).Str("tool", record.Tool).Msg("Failed to marshal audit record")
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.out.Write(append(data, '\n')); err != nil {
+		log.Error().Err(err).Str("tool", record.Tool).Msg("Failed to write audit record")
+	}
+}
+
+// Close closes the audit output
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Sanitize returns a copy of tool arguments with secrets redacted and long
+// strings truncated
+func Sanitize(args map[string]interface{}) map[string]interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	sanitized := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		sanitized[key] = sanitizeValue(key, value)
+	}
+	return sanitized
+}
+
+// sanitizeValue redacts or truncates one value, descending into objects and lists
+func sanitizeValue(key string, value interface{}) interface{} {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	for _, fragment := range sensitiveArguments {
+		if strings.Contains(normalized, fragment) {
+			return "[REDACTED]"
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) > maxArgumentLength {
+			// Cut before the rune maxArgumentLength falls in
+			cut := maxArgumentLength
+			for cut > 0 && !utf8.RuneStart(v[cut]) {
+				cut--
+			}
+			return v[:cut] + "...[truncated]"
+		}
+		return v
+	case map[string]interface{}:
+		return Sanitize(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = sanitizeValue(key, item)
+		}
+		return items
+	}
+	return value
+}
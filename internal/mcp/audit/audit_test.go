@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestSanitize checks that secrets are redacted at any depth, whatever the
+// case and separators of their names, and that other values are kept
+func TestSanitize(t *testing.T) {
+	args := map[string]interface{}{
+		"name":                          "data",
+		"size":                          10.0,
+		"password":                      "hunter2",
+		"application_credential_secret": "s3cret",
+		"X-Auth-Token":                  "gAAAA",
+		"OS_PASSCODE":                   "123456",
+		"privateKey":                    "-----BEGIN",
+		"ssh-private-key":               "-----BEGIN",
+		"metadata": map[string]interface{}{
+			"owner":       "alice",
+			"db_password": "hunter2",
+			"nested":      map[string]interface{}{"Api-Token": "abc", "tier": "gold"},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"access_to": "10.0.0.0/8", "access_key": "k", "client_secret": "x"},
+			"plain",
+		},
+		"tokens": []interface{}{"a", "b"},
+	}
+
+	want := map[string]interface{}{
+		"name":                          "data",
+		"size":                          10.0,
+		"password":                      "[REDACTED]",
+		"application_credential_secret": "[REDACTED]",
+		"X-Auth-Token":                  "[REDACTED]",
+		"OS_PASSCODE":                   "[REDACTED]",
+		"privateKey":                    "[REDACTED]",
+		"ssh-private-key":               "[REDACTED]",
+		"metadata": map[string]interface{}{
+			"owner":       "alice",
+			"db_password": "[REDACTED]",
+			"nested":      map[string]interface{}{"Api-Token": "[REDACTED]", "tier": "gold"},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"access_to": "10.0.0.0/8", "access_key": "k", "client_secret": "[REDACTED]"},
+			"plain",
+		},
+		"tokens": "[REDACTED]",
+	}
+	if got := Sanitize(args); !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("Sanitize() =\n%s", gotJSON)
+	}
+	if args["password"] != "hunter2" || args["metadata"].(map[string]interface{})["db_password"] != "hunter2" {
+		t.Error("Sanitize() changed the arguments it was given")
+	}
+	if Sanitize(nil) != nil {
+		t.Error("Sanitize(nil) != nil")
+	}
+}
+
+// TestSanitizeTruncates checks that long strings, including those in lists
+// and objects, are truncated on a rune boundary
+func TestSanitizeTruncates(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"short", "data", "data"},
+		{"at limit", strings.Repeat("a", maxArgumentLength), strings.Repeat("a", maxArgumentLength)},
+		{"ascii", strings.Repeat("a", maxArgumentLength+1), strings.Repeat("a", maxArgumentLength) + "...[truncated]"},
+		// 255 bytes of ASCII put the limit inside the 3-byte rune
+		{"rune across limit", strings.Repeat("a", maxArgumentLength-1) + "€€", strings.Repeat("a", maxArgumentLength-1) + "...[truncated]"},
+		{"runes only", strings.Repeat("日", maxArgumentLength), strings.Repeat("日", maxArgumentLength/3) + "...[truncated]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sanitize(map[string]interface{}{
+				"description": tt.value,
+				"list":        []interface{}{tt.value},
+				"object":      map[string]interface{}{"value": tt.value},
+			})
+			values := []interface{}{
+				got["description"],
+				got["list"].([]interface{})[0],
+				got["object"].(map[string]interface{})["value"],
+			}
+			for _, value := range values {
+				s, _ := value.(string)
+				if s != tt.want {
+					t.Errorf("truncated to %q (%d bytes), want %q", s, len(s), tt.want)
+				}
+				if !utf8.ValidString(s) {
+					t.Errorf("truncated value %q is not valid UTF-8", s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package auth authenticates callers of the HTTP transport
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNoCredentials is returned when a request carries no bearer token
+var ErrNoCredentials = errors.New("no bearer token supplied")
+
+// Identity describes an authenticated caller
+type Identity struct {
+	Method    string                 `json:"method"` // Authentication method that accepted the caller
+	Subject   string                 `json:"subject"`
+	Name      string                 `json:"name,omitempty"`
+	ProjectID string                 `json:"project_id,omitempty"`
+	Roles     []string               `json:"roles,omitempty"`
+	Scopes    []string               `json:"scopes,omitempty"`
+	Claims    map[string]interface{} `json:"-"` // JWT claims, for role mapping
+}
+
+// Authenticator validates the credentials of an HTTP request
+type Authenticator interface {
+	// Authenticate returns the caller's identity, ErrNoCredentials when the
+	// request carries none, or another error when they are invalid
+	Authenticate(ctx context.Context, r *http.Request) (*Identity, error)
+
+	// Challenge returns the parameters of the WWW-Authenticate header sent
+	// with rejected requests
+	Challenge() string
+}
+
+// identityContextKey stores the caller's Identity in the request context
+type identityContextKey struct{}
+
+// New creates the authenticator configured for the HTTP transport, or nil
+// when callers are not authenticated
+func New(cfg *config.HTTPAuthConfig, clients *o7k.ClientSet) (Authenticator, error) {
+	switch cfg.Type {
+	case "", config.HTTPAuthNone:
+		return nil, nil
+	case config.HTTPAuthStatic:
+		return newStaticAuthenticator(cfg.TokensFile)
+	case config.HTTPAuthJWT:
+		return newJWTAuthenticator(cfg), nil
+	case config.HTTPAuthKeystone:
+		return newKeystoneAuthenticator(clients), nil
+	default:
+		return nil, fmt.Errorf("unsupported HTTP auth type %q", cfg.Type)
+	}
+}
+
+// NewContext returns a context carrying the caller's identity
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// FromContext returns the caller's identity, or nil for unauthenticated transports
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return identity
+}
+
+// Middleware rejects requests the authenticator does not accept with
+// 401 Unauthorized and passes the caller's identity on to next
+func Middleware(authn Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := authn.Authenticate(r.Context(), r)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("remote_addr", r.RemoteAddr).
+				Msg("Rejected unauthenticated HTTP request")
+			writeUnauthorized(w, authn.Challenge(), err)
+			return
+		}
+
+		log.Debug().
+			Str("method", identity.Method).
+			Str("subject", identity.Subject).
+			Msg("Authenticated HTTP request")
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), identity)))
+	})
+}
+
+// writeUnauthorized sends an RFC 6750 bearer token challenge. Requests
+// without a token get no error code, so clients know to start authorization.
+func writeUnauthorized(w http.ResponseWriter, challenge string, err error) {
+	header := "Bearer " + challenge
+	body := map[string]string{"error": "unauthorized"}
+	if !errors.Is(err, ErrNoCredentials) {
+		header += `, error="invalid_token", error_description="the access token is invalid or expired"`
+		body = map[string]string{
+			"error":             "invalid_token",
+			"error_description": "the access token is invalid or expired",
+		}
+	}
+
+	w.Header().Set("WWW-Authenticate", header)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// bearerToken returns the token of the Authorization header
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
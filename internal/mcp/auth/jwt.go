@@ -0,0 +1,366 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// ProtectedResourceMetadataPath is where OAuth clients discover the
+// authorization server of the MCP endpoint (RFC 9728)
+const ProtectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+// jwksRefreshInterval limits how often an unknown key ID triggers a JWKS
+// fetch, so forged tokens cannot hammer the authorization server
+const jwksRefreshInterval = time.Minute
+
+// jwksRetryInterval is how soon a failed JWKS fetch may be retried, so a
+// brief outage of the authorization server does not reject tokens for long
+const jwksRetryInterval = 5 * time.Second
+
+// jwksFetchTimeout bounds a JWKS fetch, including discovery
+const jwksFetchTimeout = 30 * time.Second
+
+// jwtSignatureAlgorithms are the accepted JWS algorithms; "none" and HMAC
+// are excluded as the keys come from a public JWKS
+var jwtSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// jwtAuthenticator accepts OAuth 2.1 access tokens in JWT format issued by
+// the configured authorization server, acting as resource server
+type jwtAuthenticator struct {
+	issuer      string
+	jwksURL     string
+	audience    string
+	resourceURL string
+	rolesClaim  string
+	httpClient  *http.Client
+
+	mu        sync.Mutex
+	keys      *jose.JSONWebKeySet
+	nextFetch time.Time     // Earliest time an unknown key ID may trigger a fetch
+	fetching  chan struct{} // Closed when the fetch in flight completes
+	fetchErr  error         // Of the last fetch
+}
+
+// accessTokenClaims are the non-registered claims mapped to an Identity
+type accessTokenClaims struct {
+	PreferredUsername string `json:"preferred_username"`
+	Name              string `json:"name"`
+	ClientID          string `json:"client_id"`
+}
+
+// newJWTAuthenticator creates a JWT authenticator; keys are fetched in the
+// background, so an unreachable authorization server does not block startup
+func newJWTAuthenticator(cfg *config.HTTPAuthConfig) *jwtAuthenticator {
+	audience := cfg.Audience
+	if audience == "" {
+		audience = cfg.ResourceURL
+	}
+	rolesClaim := cfg.RolesClaim
+	if rolesClaim == "" {
+		rolesClaim = "roles"
+	}
+	a := &jwtAuthenticator{
+		issuer:      cfg.Issuer,
+		jwksURL:     cfg.JWKSURL,
+		audience:    audience,
+		resourceURL: cfg.ResourceURL,
+		rolesClaim:  rolesClaim,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+
+	a.mu.Lock()
+	a.startFetchLocked(context.Background())
+	a.mu.Unlock()
+	return a
+}
+
+// Authenticate validates the signature, issuer, audience and lifetime of
+// the bearer token
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*Identity, error) {
+	raw := bearerToken(r)
+	if raw == "" {
+		return nil, ErrNoCredentials
+	}
+
+	token, err := jwt.ParseSigned(raw, jwtSignatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWT: %w", err)
+	}
+	if len(token.Headers) != 1 {
+		return nil, fmt.Errorf("JWT must carry exactly one signature")
+	}
+
+	key, err := a.key(ctx, token.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var registered jwt.Claims
+	var extra accessTokenClaims
+	all := map[string]interface{}{}
+	if err := token.Claims(key, &registered, &extra, &all); err != nil {
+		return nil, fmt.Errorf("verifying JWT: %w", err)
+	}
+	if registered.Expiry == nil {
+		return nil, fmt.Errorf("JWT has no expiry")
+	}
+	expected := jwt.Expected{
+		Issuer:      a.issuer,
+		AnyAudience: jwt.Audience{a.audience},
+		Time:        time.Now(),
+	}
+	if err := registered.ValidateWithLeeway(expected, 30*time.Second); err != nil {
+		return nil, fmt.Errorf("validating JWT claims: %w", err)
+	}
+
+	scopes := stringsClaim(all["scope"])
+	if scopes == nil {
+		scopes = stringsClaim(all["scp"])
+	}
+
+	name := extra.PreferredUsername
+	if name == "" {
+		name = extra.Name
+	}
+	if name == "" {
+		name = extra.ClientID
+	}
+	return &Identity{
+		Method:  config.HTTPAuthJWT,
+		Subject: registered.Subject,
+		Name:    name,
+		Roles:   stringsClaim(nestedClaim(all, a.rolesClaim)),
+		Scopes:  scopes,
+		Claims:  all,
+	}, nil
+}
+
+// Challenge returns the WWW-Authenticate parameters, pointing clients to
+// the protected resource metadata when the public URL is known
+func (a *jwtAuthenticator) Challenge() string {
+	challenge := `realm="openstack-mcp-server"`
+	if metadataURL := protectedResourceMetadataURL(a.resourceURL); metadataURL != "" {
+		challenge += fmt.Sprintf(`, resource_metadata="%s"`, metadataURL)
+	}
+	return challenge
+}
+
+// key returns the verification key of a key ID, refreshing the key set when
+// the ID is unknown, e.g. after the authorization server rotated its keys.
+// Callers wait for the fetch in flight instead of starting their own.
+func (a *jwtAuthenticator) key(ctx context.Context, kid string) (*jose.JSONWebKey, error) {
+	a.mu.Lock()
+	if k := a.lookup(kid); k != nil {
+		a.mu.Unlock()
+		return k, nil
+	}
+	fetching := a.fetching
+	if fetching == nil {
+		if time.Now().Before(a.nextFetch) {
+			a.mu.Unlock()
+			return nil, fmt.Errorf("unknown JWT key ID %q", kid)
+		}
+		fetching = a.startFetchLocked(ctx)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-fetching:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if k := a.lookup(kid); k != nil {
+		return k, nil
+	}
+	if a.fetchErr != nil {
+		return nil, a.fetchErr
+	}
+	return nil, fmt.Errorf("unknown JWT key ID %q", kid)
+}
+
+// startFetchLocked fetches the key set in the background, without the lock
+// and detached from the cancellation of the request that needed it, and
+// returns a channel closed once the fetch completes. A failed fetch may be
+// retried sooner than a successful one is refreshed. The caller holds the
+// lock.
+func (a *jwtAuthenticator) startFetchLocked(ctx context.Context) chan struct{} {
+	done := make(chan struct{})
+	a.fetching = done
+
+	go func() {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+		defer cancel()
+		keys, err := a.fetchKeys(fetchCtx)
+
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.fetching = nil
+		a.fetchErr = err
+		if err != nil {
+			log.Error().Err(err).Str("issuer", a.issuer).Msg("Failed to fetch JWKS")
+			a.nextFetch = time.Now().Add(jwksRetryInterval)
+		} else {
+			a.keys = keys
+			a.nextFetch = time.Now().Add(jwksRefreshInterval)
+		}
+		close(done)
+	}()
+	return done
+}
+
+// lookup finds a key by ID; a token without key ID matches a single-key set.
+// The caller holds the lock.
+func (a *jwtAuthenticator) lookup(kid string) *jose.JSONWebKey {
+	if a.keys == nil {
+		return nil
+	}
+	if kid == "" {
+		if len(a.keys.Keys) == 1 {
+			return &a.keys.Keys[0]
+		}
+		return nil
+	}
+	if keys := a.keys.Key(kid); len(keys) > 0 {
+		return &keys[0]
+	}
+	return nil
+}
+
+// fetchKeys downloads the key set, discovering its URL from the issuer's
+// authorization server or OpenID Connect metadata when not configured
+func (a *jwtAuthenticator) fetchKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	jwksURL := a.jwksURL
+	if jwksURL == "" {
+		discovered, err := a.discoverJWKSURL(ctx)
+		if err != nil {
+			return nil, err
+		}
+		jwksURL = discovered
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := a.getJSON(ctx, jwksURL, &keys); err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	log.Info().
+		Str("jwks_url", jwksURL).
+		Int("keys", len(keys.Keys)).
+		Msg("Loaded JWT signing keys")
+	return &keys, nil
+}
+
+// discoverJWKSURL reads jwks_uri from the issuer's metadata
+func (a *jwtAuthenticator) discoverJWKSURL(ctx context.Context) (string, error) {
+	issuer := strings.TrimSuffix(a.issuer, "/")
+	var lastErr error
+	for _, path := range []string{"/.well-known/oauth-authorization-server", "/.well-known/openid-configuration"} {
+		var metadata struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := a.getJSON(ctx, issuer+path, &metadata); err != nil {
+			lastErr = err
+			continue
+		}
+		if metadata.JWKSURI != "" {
+			return metadata.JWKSURI, nil
+		}
+	}
+	return "", fmt.Errorf("discovering JWKS URL of issuer %s: %v", a.issuer, lastErr)
+}
+
+// getJSON fetches a JSON document
+func (a *jwtAuthenticator) getJSON(ctx context.Context, target string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", target, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// nestedClaim returns a claim by dot-separated path, e.g. realm_access.roles
+func nestedClaim(claims map[string]interface{}, path string) interface{} {
+	var value interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// stringsClaim reads a claim holding a string or a list of strings
+func stringsClaim(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// protectedResourceMetadataURL returns the metadata URL of a resource: the
+// well-known path inserted between its host and path
+func protectedResourceMetadataURL(resourceURL string) string {
+	u, err := url.Parse(resourceURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	u.Path = ProtectedResourceMetadataPath + strings.TrimSuffix(u.Path, "/")
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// ProtectedResourceMetadataHandler serves the OAuth protected resource
+// metadata naming the authorization server that issues access tokens
+func ProtectedResourceMetadataHandler(cfg *config.HTTPAuthConfig) http.Handler {
+	metadata := map[string]interface{}{
+		"authorization_servers":    []string{cfg.Issuer},
+		"bearer_methods_supported": []string{"header"},
+	}
+	if cfg.ResourceURL != "" {
+		metadata["resource"] = cfg.ResourceURL
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(metadata)
+	})
+}
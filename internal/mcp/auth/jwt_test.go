@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+)
+
+const testAudience = "https://mcp.example.com/mcp"
+
+// testIssuer is an authorization server publishing its metadata and JWKS
+type testIssuer struct {
+	*httptest.Server
+	key     *ecdsa.PrivateKey
+	fetches atomic.Int32 // JWKS requests
+	failing atomic.Bool  // Answer JWKS requests with an error
+}
+
+// newTestIssuer starts an authorization server signing with an ES256 key
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	issuer := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"jwks_uri": issuer.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		issuer.fetches.Add(1)
+		if issuer.failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: key.Public(), KeyID: "k1", Algorithm: string(jose.ES256), Use: "sig"},
+		}})
+	})
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+// sign issues a token with the given key ID and claims
+func (i *testIssuer) sign(t *testing.T, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: i.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", kid),
+	)
+	if err != nil {
+		t.Fatalf("creating signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return token
+}
+
+// authenticate authenticates a request carrying the bearer token
+func authenticate(a *jwtAuthenticator, ctx context.Context, token string) (*Identity, error) {
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return a.Authenticate(ctx, r)
+}
+
+// TestJWTAuthenticate checks the signature, issuer, audience and lifetime
+// checks, and the mapping of claims to the identity
+func TestJWTAuthenticate(t *testing.T) {
+	issuer := newTestIssuer(t)
+	a := newJWTAuthenticator(&config.HTTPAuthConfig{
+		Issuer:      issuer.URL,
+		ResourceURL: testAudience,
+		RolesClaim:  "realm_access.roles",
+	})
+
+	now := time.Now()
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":                issuer.URL,
+			"aud":                testAudience,
+			"sub":                "alice-id",
+			"preferred_username": "alice",
+			"exp":                now.Add(time.Hour).Unix(),
+			"realm_access":       map[string]interface{}{"roles": []string{"sre"}},
+			"scope":              "mcp:tools mcp:resources",
+		}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		kid     string
+		claims  map[string]interface{}
+		wantErr bool
+	}{
+		{"valid", "k1", valid(), false},
+		{"expired", "k1", with("exp", now.Add(-time.Hour).Unix()), true},
+		{"no expiry", "k1", with("exp", nil), true},
+		{"other issuer", "k1", with("iss", "https://other.example.com"), true},
+		{"other audience", "k1", with("aud", "https://other.example.com/mcp"), true},
+		{"unknown key ID", "k2", valid(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := authenticate(a, context.Background(), issuer.sign(t, tt.kid, tt.claims))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, want error: %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if identity.Subject != "alice-id" || identity.Name != "alice" {
+				t.Errorf("identity = %+v, want subject alice-id named alice", identity)
+			}
+			if !slices.Equal(identity.Roles, []string{"sre"}) {
+				t.Errorf("roles = %v, want [sre]", identity.Roles)
+			}
+			if !slices.Equal(identity.Scopes, []string{"mcp:tools", "mcp:resources"}) {
+				t.Errorf("scopes = %v, want [mcp:tools mcp:resources]", identity.Scopes)
+			}
+		})
+	}
+
+	if _, err := authenticate(a, context.Background(), "not-a-jwt"); err == nil {
+		t.Error("malformed token accepted")
+	}
+}
+
+// TestJWTKeyFetch checks that the key set is fetched once at startup, that
+// a cancelled request does not abort the fetch, and that a failed fetch is
+// retried sooner than a successful one is refreshed
+func TestJWTKeyFetch(t *testing.T) {
+	issuer := newTestIssuer(t)
+	issuer.failing.Store(true)
+	a := newJWTAuthenticator(&config.HTTPAuthConfig{Issuer: issuer.URL, Audience: testAudience})
+
+	// The startup fetch fails, and the next fetch may only start after the
+	// retry interval
+	if _, err := a.key(context.Background(), "k1"); err == nil {
+		t.Fatal("key found while the JWKS is unavailable")
+	}
+	a.mu.Lock()
+	wait := time.Until(a.nextFetch)
+	a.mu.Unlock()
+	if wait <= 0 || wait > jwksRetryInterval {
+		t.Fatalf("next fetch in %s after a failure, want at most %s", wait, jwksRetryInterval)
+	}
+
+	// A request cancelled while waiting still lets the fetch complete
+	issuer.failing.Store(false)
+	a.mu.Lock()
+	a.nextFetch = time.Time{}
+	a.mu.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a.key(ctx, "k1")
+	if _, err := a.key(context.Background(), "k1"); err != nil {
+		t.Fatalf("key after the fetch completed: %v", err)
+	}
+
+	// Known keys are served without fetching, unknown ones are rate limited
+	fetches := issuer.fetches.Load()
+	for range 10 {
+		if _, err := a.key(context.Background(), "k1"); err != nil {
+			t.Fatalf("key: %v", err)
+		}
+		a.key(context.Background(), "unknown")
+	}
+	if got := issuer.fetches.Load(); got != fetches {
+		t.Errorf("%d JWKS fetches after the keys were loaded, want none", got-fetches)
+	}
+}
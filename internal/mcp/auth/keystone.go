@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+)
+
+// keystoneCacheTTL bounds how long a validated token is trusted without
+// asking Keystone again, so revocations take effect quickly
+const keystoneCacheTTL = time.Minute
+
+// keystoneAuthenticator accepts Keystone tokens sent as bearer token or in
+// the X-Auth-Token header
+type keystoneAuthenticator struct {
+	clients *o7k.ClientSet
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]keystoneCacheEntry
+}
+
+// keystoneCacheEntry is a validated token and when to validate it again
+type keystoneCacheEntry struct {
+	identity  *Identity
+	expiresAt time.Time
+}
+
+// newKeystoneAuthenticator creates an authenticator validating tokens
+// against the Keystone of the default cloud
+func newKeystoneAuthenticator(clients *o7k.ClientSet) *keystoneAuthenticator {
+	return &keystoneAuthenticator{
+		clients: clients,
+		cache:   map[[sha256.Size]byte]keystoneCacheEntry{},
+	}
+}
+
+// Authenticate validates the caller's Keystone token
+func (a *keystoneAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	if token == "" {
+		token = r.Header.Get("X-Auth-Token")
+	}
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	now := time.Now()
+	a.mu.Lock()
+	entry, ok := a.cache[hash]
+	a.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.identity, nil
+	}
+
+	validated, err := a.clients.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Method:    config.HTTPAuthKeystone,
+		Subject:   validated.UserID,
+		Name:      validated.UserName,
+		ProjectID: validated.ProjectID,
+		Roles:     validated.Roles,
+	}
+	expiresAt := now.Add(keystoneCacheTTL)
+	if validated.ExpiresAt.Before(expiresAt) {
+		expiresAt = validated.ExpiresAt
+	}
+
+	a.mu.Lock()
+	for key, cached := range a.cache {
+		if now.After(cached.expiresAt) {
+			delete(a.cache, key)
+		}
+	}
+	a.cache[hash] = keystoneCacheEntry{identity: identity, expiresAt: expiresAt}
+	a.mu.Unlock()
+
+	return identity, nil
+}
+
+// Challenge returns the WWW-Authenticate parameters
+func (a *keystoneAuthenticator) Challenge() string {
+	return `realm="openstack-mcp-server"`
+}
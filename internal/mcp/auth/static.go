@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// staticAuthenticator accepts the bearer tokens listed in a file. Each line
+// holds a token, optionally preceded by a client name: "ci-bot <token>".
+// The file is re-read when it changes, so rotated secrets apply without a
+// restart.
+type staticAuthenticator struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	tokens  map[[sha256.Size]byte]string // Token hash to client name
+}
+
+// newStaticAuthenticator loads the tokens file
+func newStaticAuthenticator(path string) (*staticAuthenticator, error) {
+	a := &staticAuthenticator{path: path}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Authenticate accepts requests carrying one of the listed tokens
+func (a *staticAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+
+	if err := a.reload(); err != nil {
+		// Keep serving with the tokens loaded last
+		log.Error().Err(err).Str("tokens_file", a.path).Msg("Failed to reload tokens file")
+	}
+
+	// Tokens are compared by hash, so lookups do not leak their prefix
+	hash := sha256.Sum256([]byte(token))
+	a.mu.Lock()
+	name, ok := a.tokens[hash]
+	a.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown bearer token")
+	}
+
+	// The client name doubles as role for role-based tool access
+	return &Identity{
+		Method:  config.HTTPAuthStatic,
+		Subject: name,
+		Name:    name,
+		Roles:   []string{name},
+	}, nil
+}
+
+// Challenge returns the WWW-Authenticate parameters
+func (a *staticAuthenticator) Challenge() string {
+	return `realm="openstack-mcp-server"`
+}
+
+// reload re-reads the tokens file when it changed since the last read
+func (a *staticAuthenticator) reload() error {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return fmt.Errorf("reading tokens file: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.tokens != nil && info.ModTime().Equal(a.modTime) {
+		return nil
+	}
+
+	f, err := os.Open(a.path)
+	if err != nil {
+		return fmt.Errorf("reading tokens file: %w", err)
+	}
+	defer f.Close()
+
+	tokens := map[[sha256.Size]byte]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		token := fields[len(fields)-1]
+		hash := sha256.Sum256([]byte(token))
+		name := "token-" + hex.EncodeToString(hash[:4])
+		if len(fields) > 1 {
+			name = fields[0]
+		}
+		tokens[hash] = name
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading tokens file: %w", err)
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("no tokens found in %s", a.path)
+	}
+
+	a.tokens = tokens
+	a.modTime = info.ModTime()
+	log.Info().
+		Str("tokens_file", a.path).
+		Int("tokens", len(tokens)).
+		Msg("Loaded HTTP bearer tokens")
+	return nil
+}
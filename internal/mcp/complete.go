@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/handlers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// Completion reference types: MCP completes the arguments of prompts and
+// resource templates
+const (
+	refPrompt   = "ref/prompt"
+	refResource = "ref/resource"
+)
+
+// completions answers completion/complete requests
+type completions struct {
+	completer *handlers.Completer
+}
+
+// completeParams are the params of a completion/complete request
+type completeParams struct {
+	Ref struct {
+		Type string `json:"type"`
+		Name string `json:"name,omitempty"` // Prompts
+		URI  string `json:"uri,omitempty"`  // Resource templates
+	} `json:"ref"`
+	Argument struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"argument"`
+
+	// Arguments already given, whose cloud and region select the cloud
+	// completions are listed from
+	Context struct {
+		Arguments map[string]string `json:"arguments"`
+	} `json:"context"`
+}
+
+// handle completes an argument of a prompt or resource template.
+// Arguments are completed by name, and resource templates name their
+// variable after the tool argument taking the same ID, e.g. volume_id.
+func (c *completions) handle(ctx context.Context, request *methodRequest) mcp.JSONRPCMessage {
+	var params completeParams
+	if err := json.Unmarshal(request.Params, &params); err != nil {
+		return methodError(request.ID, mcp.INVALID_PARAMS, "invalid completion params")
+	}
+	switch params.Ref.Type {
+	case refPrompt, refResource:
+	default:
+		return methodError(request.ID, mcp.INVALID_PARAMS, "unsupported completion reference type: "+params.Ref.Type)
+	}
+	if params.Argument.Name == "" {
+		return methodError(request.ID, mcp.INVALID_PARAMS, "missing argument name")
+	}
+
+	values, total := c.completer.Complete(ctx, params.Argument.Name, params.Argument.Value, params.Context.Arguments)
+	log.Debug().
+		Str("argument", params.Argument.Name).
+		Str("value", params.Argument.Value).
+		Int("total", total).
+		Msg("Completed argument")
+
+	var result mcp.CompleteResult
+	result.Completion.Values = values
+	if result.Completion.Values == nil {
+		result.Completion.Values = []string{}
+	}
+	result.Completion.Total = total
+	result.Completion.HasMore = total > len(values)
+	return mcp.NewJSONRPCResultResponse(request.ID, result)
+}
@@ -0,0 +1,29 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+)
+
+// HTTP headers carrying per-request OpenStack credentials
+const (
+	headerAuthToken                   = "X-Auth-Token"
+	headerApplicationCredentialID     = "X-OpenStack-Application-Credential-Id"
+	headerApplicationCredentialSecret = "X-OpenStack-Application-Credential-Secret"
+)
+
+// requestCredentialsContext stores the OpenStack credentials sent with an
+// HTTP request in its context, for the cloud middleware to pick up
+func requestCredentialsContext(ctx context.Context, r *http.Request) context.Context {
+	creds := o7k.RequestCredentials{
+		Token:                       r.Header.Get(headerAuthToken),
+		ApplicationCredentialID:     r.Header.Get(headerApplicationCredentialID),
+		ApplicationCredentialSecret: r.Header.Get(headerApplicationCredentialSecret),
+	}
+	if creds.IsZero() {
+		return ctx
+	}
+	return o7k.WithRequestCredentials(ctx, creds)
+}
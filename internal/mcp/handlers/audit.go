@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/audit"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AuditMiddleware records every tool call, including calls the policy
+// rejects, with the caller, the OpenStack request IDs it caused, its
+// outcome and duration
+func AuditMiddleware(logger *audit.Logger, policy *Policy) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool := request.Params.Name
+			readOnly := policy.readOnlyTools[tool]
+			if !logger.Sampled(readOnly) {
+				return next(ctx, request)
+			}
+
+			ctx, requestIDs := o7k.WithRequestIDs(ctx)
+			ctx, resolved := withResolvedIDs(ctx)
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			record := audit.Record{
+				Time:       start.UTC(),
+				Tool:       tool,
+				ReadOnly:   readOnly,
+				DryRun:     policy.isDryRun(tool, request),
+				Arguments:  audit.Sanitize(request.GetArguments()),
+				Resolved:   resolved,
+				Caller:     auth.FromContext(ctx),
+				RequestIDs: requestIDs.List(),
+				Status:     "success",
+				DurationMS: time.Since(start).Milliseconds(),
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				record.SessionID = session.SessionID()
+			}
+			switch {
+			case err != nil:
+				record.Status = "error"
+				record.Error = err.Error()
+			case result != nil && result.IsError:
+				record.Status = "error"
+				record.Error = resultText(result)
+			}
+			logger.Log(record)
+
+			return result, err
+		}
+	}
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
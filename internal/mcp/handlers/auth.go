@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// AuthHandler handles authentication MCP tool execution requests and delegates to OpenStack client
+type AuthHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(clients *o7k.ClientSet) *AuthHandler {
+	return &AuthHandler{
+		clients: clients,
+	}
+}
+
+// HandleTokenInfo handles the auth_token_info tool
+func (h *AuthHandler) HandleTokenInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing auth_token_info tool")
+
+	info, err := h.clients.FromContext(ctx).TokenInfo()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get token info")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get token info: %v", err)), nil
+	}
+
+	return newJSONResult(info, "token info"), nil
+}
+
+// HandleReauthenticate handles the auth_reauthenticate tool
+func (h *AuthHandler) HandleReauthenticate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing auth_reauthenticate tool")
+
+	info, err := h.clients.FromContext(ctx).Reauthenticate(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to re-authenticate")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to re-authenticate: %v", err)), nil
+	}
+
+	return newJSONResult(info, "token info"), nil
+}
+
+// RegisterTools registers all auth tools with the MCP server
+func (h *AuthHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering auth tools")
+
+	registerToolDefinitions(mcpServer, "auth", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// getToolDefinitions returns all auth tool definitions
+func (h *AuthHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "auth_token_info",
+			Description: "Show the user, project and expiry of the current OpenStack token",
+			ReadOnly:    true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("auth_token_info",
+					mcp.WithDescription("Show the auth type, user, project and expiry of the Keystone token the server uses, and whether it can be renewed automatically."),
+					withOutputSchema[o7k.TokenInfo](),
+				)
+			},
+			Handler: h.HandleTokenInfo,
+		},
+		{
+			// Issues a new Keystone token, so it is hidden in read-only mode
+			Name:        "auth_reauthenticate",
+			Description: "Force the server to obtain a new OpenStack token",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("auth_reauthenticate",
+					mcp.WithDescription("Obtain a new Keystone token with the configured credentials, e.g. after role assignments changed. Expired tokens are renewed automatically; this is only needed to pick up changes early."),
+					withOutputSchema[o7k.TokenInfo](),
+				)
+			},
+			Handler: h.HandleReauthenticate,
+		},
+	}
+}
@@ -0,0 +1,507 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// BaremetalHandler handles bare metal (Ironic) MCP tool execution requests and delegates to OpenStack client
+type BaremetalHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewBaremetalHandler creates a new bare metal handler
+func NewBaremetalHandler(clients *o7k.ClientSet) *BaremetalHandler {
+	return &BaremetalHandler{
+		clients: clients,
+	}
+}
+
+// BaremetalNodesListArgs defines the filters for listing bare metal nodes
+type BaremetalNodesListArgs struct {
+	ProvisionState string `json:"provision_state,omitempty"`
+	PowerState     string `json:"power_state,omitempty"`
+	Maintenance    *bool  `json:"maintenance,omitempty"`
+	ResourceClass  string `json:"resource_class,omitempty"`
+}
+
+// HandleListNodes handles the baremetal_nodes_list tool
+func (h *BaremetalHandler) HandleListNodes(ctx context.Context, request mcp.CallToolRequest, args BaremetalNodesListArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_nodes_list tool")
+
+	opts := o7k.ListBaremetalNodesOpts{
+		ProvisionState: args.ProvisionState,
+		PowerState:     args.PowerState,
+		Maintenance:    args.Maintenance,
+		ResourceClass:  args.ResourceClass,
+	}
+
+	nodes, err := h.clients.FromContext(ctx).ListBaremetalNodes(ctx, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list bare metal nodes")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list bare metal nodes: %v", err)), nil
+	}
+
+	log.Debug().
+		Int("count", len(nodes)).
+		Msg("Bare metal nodes listed successfully")
+
+	return newListResult(nodes, "bare metal nodes"), nil
+}
+
+// HandleGetNode handles the baremetal_node_get tool
+func (h *BaremetalHandler) HandleGetNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_get tool")
+
+	nodeID := request.GetString("node_id", "")
+	if nodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+
+	node, err := h.clients.FromContext(ctx).GetBaremetalNode(ctx, nodeID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Msg("Failed to get bare metal node")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get bare metal node: %v", err)), nil
+	}
+
+	return newJSONResult(node, "bare metal node"), nil
+}
+
+// HandleListPorts handles the baremetal_node_ports_list tool
+func (h *BaremetalHandler) HandleListPorts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_ports_list tool")
+
+	nodeID := request.GetString("node_id", "")
+	if nodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+
+	ports, err := h.clients.FromContext(ctx).ListBaremetalPorts(ctx, nodeID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Msg("Failed to list bare metal ports")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list bare metal ports: %v", err)), nil
+	}
+
+	return newListResult(ports, "bare metal ports"), nil
+}
+
+// HandleValidateNode handles the baremetal_node_validate tool
+func (h *BaremetalHandler) HandleValidateNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_validate tool")
+
+	nodeID := request.GetString("node_id", "")
+	if nodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+
+	validation, err := h.clients.FromContext(ctx).ValidateBaremetalNode(ctx, nodeID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Msg("Failed to validate bare metal node")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to validate bare metal node: %v", err)), nil
+	}
+
+	return newListResult(validation, "validation result"), nil
+}
+
+// BaremetalNodeActionResult is returned by the tools changing the power,
+// provision or maintenance state of a node
+type BaremetalNodeActionResult struct {
+	Success bool   `json:"success"`
+	NodeID  string `json:"node_id"`
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message"`
+}
+
+// BaremetalNodeActionArgs defines the arguments for power and provision state changes
+type BaremetalNodeActionArgs struct {
+	NodeID string `json:"node_id"`
+	Action string `json:"action"`
+}
+
+// HandleSetPowerState handles the baremetal_node_power tool
+func (h *BaremetalHandler) HandleSetPowerState(ctx context.Context, request mcp.CallToolRequest, args BaremetalNodeActionArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_power tool")
+
+	if args.NodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+	if args.Action == "" {
+		return mcp.NewToolResultError("Missing or invalid 'action' parameter"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).SetBaremetalPowerState(ctx, args.NodeID, args.Action); err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", args.NodeID).
+			Str("action", args.Action).
+			Msg("Failed to change bare metal node power state")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to change power state: %v", err)), nil
+	}
+
+	result := BaremetalNodeActionResult{
+		Success: true,
+		NodeID:  args.NodeID,
+		Action:  args.Action,
+		Message: "Power state change requested",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// BaremetalMaintenanceArgs defines the arguments for setting maintenance mode
+type BaremetalMaintenanceArgs struct {
+	NodeID string `json:"node_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// HandleSetMaintenance handles the baremetal_node_maintenance_set tool
+func (h *BaremetalHandler) HandleSetMaintenance(ctx context.Context, request mcp.CallToolRequest, args BaremetalMaintenanceArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_maintenance_set tool")
+
+	if args.NodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).SetBaremetalMaintenance(ctx, args.NodeID, args.Reason); err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", args.NodeID).
+			Msg("Failed to set bare metal node maintenance")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to set maintenance: %v", err)), nil
+	}
+
+	result := BaremetalNodeActionResult{
+		Success: true,
+		NodeID:  args.NodeID,
+		Message: "Node placed in maintenance mode",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// HandleUnsetMaintenance handles the baremetal_node_maintenance_unset tool
+func (h *BaremetalHandler) HandleUnsetMaintenance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_maintenance_unset tool")
+
+	nodeID := request.GetString("node_id", "")
+	if nodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).UnsetBaremetalMaintenance(ctx, nodeID); err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Msg("Failed to unset bare metal node maintenance")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to unset maintenance: %v", err)), nil
+	}
+
+	result := BaremetalNodeActionResult{
+		Success: true,
+		NodeID:  nodeID,
+		Message: "Node taken out of maintenance mode",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// BaremetalProvisionArgs defines the arguments for provision state changes
+type BaremetalProvisionArgs struct {
+	BaremetalNodeActionArgs
+	CleanSteps []o7k.BaremetalCleanStep `json:"clean_steps,omitempty"`
+}
+
+// HandleSetProvisionState handles the baremetal_node_provision tool
+func (h *BaremetalHandler) HandleSetProvisionState(ctx context.Context, request mcp.CallToolRequest, args BaremetalProvisionArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing baremetal_node_provision tool")
+
+	if args.NodeID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_id' parameter"), nil
+	}
+	if args.Action == "" {
+		return mcp.NewToolResultError("Missing or invalid 'action' parameter"), nil
+	}
+	if args.Action == "clean" && len(args.CleanSteps) == 0 {
+		return mcp.NewToolResultError("The 'clean' action requires the 'clean_steps' parameter"), nil
+	}
+	for _, step := range args.CleanSteps {
+		if step.Interface == "" || step.Step == "" {
+			return mcp.NewToolResultError("Each clean step requires 'interface' and 'step'"), nil
+		}
+	}
+
+	if err := h.clients.FromContext(ctx).SetBaremetalProvisionState(ctx, args.NodeID, args.Action, args.CleanSteps); err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", args.NodeID).
+			Str("action", args.Action).
+			Msg("Failed to change bare metal node provision state")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to change provision state: %v", err)), nil
+	}
+
+	result := BaremetalNodeActionResult{
+		Success: true,
+		NodeID:  args.NodeID,
+		Action:  args.Action,
+		Message: "Provision state change requested",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// RegisterTools registers all bare metal tools with the MCP server
+func (h *BaremetalHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering bare metal tools")
+
+	registerToolDefinitions(mcpServer, "baremetal", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// RegisterResources registers the bare metal node resources with the MCP server
+func (h *BaremetalHandler) RegisterResources(mcpServer *server.MCPServer, policy *Policy) error {
+	registerResourceDefinitions(mcpServer, "baremetal", []ResourceDefinition{
+		{
+			URI:         ResourceScheme + "baremetal/nodes",
+			Name:        "Bare metal nodes",
+			Description: "All bare metal nodes",
+			Tool:        "baremetal_nodes_list",
+			Read: func(ctx context.Context, _ string) (interface{}, error) {
+				return h.clients.FromContext(ctx).ListBaremetalNodes(ctx, o7k.ListBaremetalNodesOpts{})
+			},
+		},
+		{
+			URI:         ResourceScheme + "baremetal/nodes/{node_id}",
+			Name:        "Bare metal node",
+			Description: "A bare metal node by UUID or name",
+			Tool:        "baremetal_node_get",
+			Read: func(ctx context.Context, id string) (interface{}, error) {
+				return h.clients.FromContext(ctx).GetBaremetalNode(ctx, id)
+			},
+		},
+	}, policy)
+
+	return nil
+}
+
+// nodeTarget looks up the node of a call for confirmation
+func (h *BaremetalHandler) nodeTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	node, err := h.clients.FromContext(ctx).GetBaremetalNode(ctx, request.GetString("node_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmTarget{
+		Type:   "bare metal node",
+		ID:     node.ID,
+		Name:   node.Name,
+		Status: fmt.Sprintf("%s, %s", node.ProvisionState, node.PowerState),
+	}, nil
+}
+
+// getToolDefinitions returns all bare metal tool definitions
+func (h *BaremetalHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "baremetal_nodes_list",
+			Description: "List bare metal nodes",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_nodes_list",
+					mcp.WithDescription("List bare metal (Ironic) nodes with their power, provision and maintenance state. Optionally filter by provision state, power state, maintenance flag or resource class."),
+					withOutputSchema[listResult[o7k.BaremetalNode]](),
+					mcp.WithString("provision_state",
+						mcp.Description("Optional provision state filter (e.g., 'available', 'active', 'manageable', 'clean failed')"),
+					),
+					mcp.WithString("power_state",
+						mcp.Description("Optional power state filter"),
+						mcp.Enum("power on", "power off"),
+					),
+					mcp.WithBoolean("maintenance",
+						mcp.Description("Optional filter on the maintenance flag"),
+					),
+					mcp.WithString("resource_class",
+						mcp.Description("Optional resource class filter"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleListNodes),
+		},
+		{
+			Name:        "baremetal_node_get",
+			Description: "Get details of a specific bare metal node",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_get",
+					mcp.WithDescription("Get detailed information about a bare metal node, including last error, fault, properties and traits."),
+					withOutputSchema[o7k.BaremetalNode](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+				)
+			},
+			Handler: h.HandleGetNode,
+		},
+		{
+			Name:        "baremetal_node_ports_list",
+			Description: "List the ports of a bare metal node",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_ports_list",
+					mcp.WithDescription("List the network ports (MAC addresses, PXE flag, switch connection) of a bare metal node."),
+					withOutputSchema[listResult[o7k.BaremetalPort]](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+				)
+			},
+			Handler: h.HandleListPorts,
+		},
+		{
+			Name:        "baremetal_node_validate",
+			Description: "Validate the driver interfaces of a bare metal node",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_validate",
+					mcp.WithDescription("Validate whether the node's driver has enough information to manage it. Returns the result and failure reason for each driver interface."),
+					withOutputSchema[listResult[o7k.BaremetalInterfaceValidation]](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+				)
+			},
+			Handler: h.HandleValidateNode,
+		},
+		{
+			Name:        "baremetal_node_power",
+			Description: "Power a bare metal node on or off, or reboot it (admin)",
+			ReadOnly:    false,
+			Destructive: true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_power",
+					mcp.WithDescription("Power a bare metal node on or off, or reboot it. Requires bare metal admin privileges. Powering off or rebooting an active node interrupts its workload."),
+					withOutputSchema[BaremetalNodeActionResult](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+					mcp.WithString("action",
+						mcp.Required(),
+						mcp.Description("Power action to perform"),
+						mcp.Enum("on", "off", "reboot"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleSetPowerState),
+			Target:  h.nodeTarget,
+		},
+		{
+			Name:        "baremetal_node_maintenance_set",
+			Description: "Put a bare metal node into maintenance mode (admin)",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_maintenance_set",
+					mcp.WithDescription("Put a bare metal node into maintenance mode so Ironic stops managing it. Requires bare metal admin privileges."),
+					withOutputSchema[BaremetalNodeActionResult](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+					mcp.WithString("reason",
+						mcp.Description("Optional reason recorded on the node"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleSetMaintenance),
+		},
+		{
+			Name:        "baremetal_node_maintenance_unset",
+			Description: "Take a bare metal node out of maintenance mode (admin)",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_maintenance_unset",
+					mcp.WithDescription("Take a bare metal node out of maintenance mode. Requires bare metal admin privileges."),
+					withOutputSchema[BaremetalNodeActionResult](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+				)
+			},
+			Handler: h.HandleUnsetMaintenance,
+		},
+		{
+			Name:        "baremetal_node_provision",
+			Description: "Change the provision state of a bare metal node (admin)",
+			ReadOnly:    false,
+			Destructive: true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("baremetal_node_provision",
+					mcp.WithDescription("Request a provision state transition for a bare metal node: 'manage' (enroll/available -> manageable), 'provide' (manageable -> available, runs automated cleaning), 'deploy' (available -> active) or 'clean' (manual cleaning of a manageable node, running the given clean_steps). Requires bare metal admin privileges."),
+					withOutputSchema[BaremetalNodeActionResult](),
+					mcp.WithString("node_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node"),
+					),
+					mcp.WithString("action",
+						mcp.Required(),
+						mcp.Description("Provision action to perform"),
+						mcp.Enum("manage", "provide", "deploy", "clean"),
+					),
+					mcp.WithArray("clean_steps",
+						mcp.Description("Clean steps to run, in order; required for 'clean' and not allowed otherwise (e.g., [{\"interface\": \"deploy\", \"step\": \"erase_devices_metadata\"}])"),
+						mcp.Items(map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"interface": map[string]any{
+									"type":        "string",
+									"description": "Driver interface running the step",
+									"enum":        []string{"bios", "deploy", "firmware", "management", "power", "raid"},
+								},
+								"step": map[string]any{
+									"type":        "string",
+									"description": "Name of the step (e.g., 'erase_devices_metadata', 'erase_devices')",
+								},
+								"args": map[string]any{
+									"type":        "object",
+									"description": "Optional arguments of the step",
+								},
+							},
+							"required": []string{"interface", "step"},
+						}),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleSetProvisionState),
+			Target:  h.nodeTarget,
+		},
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// CloudHandler handles tools describing the configured clouds
+type CloudHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewCloudHandler creates a new cloud handler
+func NewCloudHandler(clients *o7k.ClientSet) *CloudHandler {
+	return &CloudHandler{
+		clients: clients,
+	}
+}
+
+// credentialsRequiredMessage answers calls without OpenStack credentials in
+// per-request credentials mode
+const credentialsRequiredMessage = "OpenStack credentials required: send an X-Auth-Token header or X-OpenStack-Application-Credential-Id and X-OpenStack-Application-Credential-Secret headers"
+
+// CloudMiddleware selects the OpenStack client of each tool call from its
+// optional cloud and region arguments and, in per-request credentials mode,
+// the caller's credentials; handlers read it with ClientSet.FromContext
+func CloudMiddleware(clients *o7k.ClientSet) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cloud := request.GetString("cloud", "")
+			region := request.GetString("region", "")
+
+			var client *o7k.Client
+			var err error
+			if clients.PerRequestCredentials() {
+				// Act with the caller's own OpenStack permissions only
+				creds, ok := o7k.RequestCredentialsFromContext(ctx)
+				if !ok {
+					return mcp.NewToolResultError(credentialsRequiredMessage), nil
+				}
+				client, err = clients.GetWithCredentials(cloud, region, creds)
+			} else {
+				if cloud == "" && region == "" {
+					return next(ctx, request)
+				}
+				client, err = clients.Get(cloud, region)
+			}
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("cloud", cloud).
+					Str("region", region).
+					Msg("Failed to select cloud")
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to select cloud: %v", err)), nil
+			}
+
+			return next(clients.NewContext(ctx, client), request)
+		}
+	}
+}
+
+// CloudResourceMiddleware selects the OpenStack client of resource reads:
+// the default cloud or, in per-request credentials mode, the caller's
+// credentials
+func CloudResourceMiddleware(clients *o7k.ClientSet) server.ResourceHandlerMiddleware {
+	return func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			if !clients.PerRequestCredentials() {
+				return next(ctx, request)
+			}
+
+			creds, ok := o7k.RequestCredentialsFromContext(ctx)
+			if !ok {
+				return nil, errors.New(credentialsRequiredMessage)
+			}
+			client, err := clients.GetWithCredentials("", "", creds)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to select cloud")
+				return nil, fmt.Errorf("selecting cloud: %w", err)
+			}
+			return next(clients.NewContext(ctx, client), request)
+		}
+	}
+}
+
+// addCloudArguments adds the optional cloud and region arguments every tool accepts
+func addCloudArguments(tool *mcp.Tool) {
+	if _, ok := tool.InputSchema.Properties["cloud"]; !ok {
+		mcp.WithString("cloud",
+			mcp.Description("Optional cloud name from clouds_list; defaults to the default cloud"),
+		)(tool)
+	}
+	if _, ok := tool.InputSchema.Properties["region"]; !ok {
+		mcp.WithString("region",
+			mcp.Description("Optional region; defaults to the region configured for the cloud"),
+		)(tool)
+	}
+}
+
+// HandleListClouds handles the clouds_list tool
+func (h *CloudHandler) HandleListClouds(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing clouds_list tool")
+
+	return newListResult(h.clients.List(), "clouds"), nil
+}
+
+// RegisterTools registers all cloud tools with the MCP server
+func (h *CloudHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering cloud tools")
+
+	registerToolDefinitions(mcpServer, "cloud", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// getToolDefinitions returns all cloud tool definitions
+func (h *CloudHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "clouds_list",
+			Description: "List the configured clouds, projects and regions",
+			ReadOnly:    true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("clouds_list",
+					mcp.WithDescription("List the clouds this server can reach with their auth URL, project and default region. Pass a cloud name (and optionally a region) as the 'cloud'/'region' argument of any other tool to run it there, e.g. to compare resources across environments."),
+					withOutputSchema[listResult[o7k.CloudInfo]](),
+				)
+			},
+			Handler: h.HandleListClouds,
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/rs/zerolog/log"
+)
+
+// completionCacheTTL is how long a listing is reused for completions, so
+// that each keystroke does not query OpenStack
+const completionCacheTTL = 30 * time.Second
+
+// MaxCompletionValues is the most values a completion returns, per MCP
+const MaxCompletionValues = 100
+
+// completionSource is the kind of resource an argument names
+type completionSource struct {
+	Kind o7k.ResourceKind
+
+	// Tool listing the resources or taking the argument; only callers
+	// permitted to call it get completions
+	Tool string
+
+	// ByName completes names instead of IDs, for arguments taking names
+	ByName bool
+}
+
+// completionSources maps the arguments of tools, prompts and resource
+// templates to the resources they name
+var completionSources = map[string]completionSource{
+	"volume_id":           {Kind: o7k.ResourceVolume, Tool: "volumes_list"},
+	"volume_type":         {Kind: o7k.ResourceVolumeType, Tool: "volume_create", ByName: true},
+	"server_id":           {Kind: o7k.ResourceServer, Tool: "servers_list"},
+	"network_id":          {Kind: o7k.ResourceNetwork, Tool: "networks_list"},
+	"share_id":            {Kind: o7k.ResourceShare, Tool: "shares_list"},
+	"share_network_id":    {Kind: o7k.ResourceShareNetwork, Tool: "share_create"},
+	"snapshot_id":         {Kind: o7k.ResourceShareSnapshot, Tool: "share_snapshots_list"},
+	"node_id":             {Kind: o7k.ResourceBaremetalNode, Tool: "baremetal_nodes_list"},
+	"cluster_id":          {Kind: o7k.ResourceCluster, Tool: "coe_clusters_list"},
+	"cluster_template_id": {Kind: o7k.ResourceClusterTemplate, Tool: "coe_cluster_templates_list"},
+	"provider_id":         {Kind: o7k.ResourcePlacementProvider, Tool: "placement_resource_providers_list"},
+}
+
+// Completer completes arguments naming OpenStack objects from live
+// listings, matching the typed value against IDs and names
+type Completer struct {
+	clients *o7k.ClientSet
+	policy  *Policy
+
+	mu    sync.Mutex
+	cache map[string]completionCacheEntry
+}
+
+// completionCacheEntry is a cached listing
+type completionCacheEntry struct {
+	candidates []o7k.NamedResource
+	expiresAt  time.Time
+}
+
+// NewCompleter creates a completer listing objects with the given clients
+func NewCompleter(clients *o7k.ClientSet, policy *Policy) *Completer {
+	return &Completer{
+		clients: clients,
+		policy:  policy,
+		cache:   map[string]completionCacheEntry{},
+	}
+}
+
+// Complete returns the values of an argument starting with value, at most
+// MaxCompletionValues, and the number of matches. arguments are the other
+// arguments already given; their cloud and region select the cloud listed.
+// Arguments that name no known object, and listings that fail, complete to
+// nothing.
+func (c *Completer) Complete(ctx context.Context, argument, value string, arguments map[string]string) ([]string, int) {
+	if argument == "cloud" {
+		var names []o7k.NamedResource
+		for _, cloud := range c.clients.List() {
+			names = append(names, o7k.NamedResource{Name: cloud.Name})
+		}
+		return matchCandidates(names, value, true)
+	}
+
+	// Only tools registered are in readOnlyTools, so those read-only mode or
+	// the policy exclude complete nothing
+	source, ok := completionSources[argument]
+	if !ok {
+		return nil, 0
+	}
+	if _, registered := c.policy.readOnlyTools[source.Tool]; !registered ||
+		!c.policy.Permits(auth.FromContext(ctx), source.Tool) {
+		return nil, 0
+	}
+
+	all, err := c.list(ctx, argument, source.Kind, arguments["cloud"], arguments["region"])
+	if err != nil {
+		log.Debug().
+			Err(err).
+			Str("argument", argument).
+			Msg("Failed to list completion candidates")
+		return nil, 0
+	}
+	return matchCandidates(all, value, source.ByName)
+}
+
+// list returns the resources of a kind, from the cache when recent enough.
+// In per-request credentials mode listings are cached per caller, who only
+// see what their own credentials list.
+func (c *Completer) list(ctx context.Context, argument string, kind o7k.ResourceKind, cloud, region string) ([]o7k.NamedResource, error) {
+	key := argument + "\x00" + cloud + "\x00" + region
+	var creds o7k.RequestCredentials
+	if c.clients.PerRequestCredentials() {
+		var ok bool
+		if creds, ok = o7k.RequestCredentialsFromContext(ctx); !ok {
+			return nil, errors.New(credentialsRequiredMessage)
+		}
+		key += "\x00" + creds.Key()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.candidates, nil
+	}
+
+	var client *o7k.Client
+	var err error
+	if c.clients.PerRequestCredentials() {
+		client, err = c.clients.GetWithCredentials(cloud, region, creds)
+	} else {
+		client, err = c.clients.Get(cloud, region)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := client.ListNamedResources(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, e := range c.cache {
+		if now.After(e.expiresAt) {
+			delete(c.cache, k)
+		}
+	}
+	c.cache[key] = completionCacheEntry{candidates: all, expiresAt: now.Add(completionCacheTTL)}
+	return all, nil
+}
+
+// matchCandidates returns the IDs, or names when byName is set, of the candidates
+// whose ID or name starts with value, ignoring case, sorted by name
+func matchCandidates(all []o7k.NamedResource, value string, byName bool) ([]string, int) {
+	prefix := strings.ToLower(value)
+	var matches []o7k.NamedResource
+	for _, candidate := range all {
+		if strings.HasPrefix(strings.ToLower(candidate.ID), prefix) ||
+			strings.HasPrefix(strings.ToLower(candidate.Name), prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	values := make([]string, 0, min(len(matches), MaxCompletionValues))
+	for _, candidate := range matches {
+		if len(values) == MaxCompletionValues {
+			break
+		}
+		if byName {
+			values = append(values, candidate.Name)
+		} else {
+			values = append(values, candidate.ID)
+		}
+	}
+	return values, len(matches)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+)
+
+// TestMatchCandidates checks prefix matching on IDs and names, ordering and
+// the limit on returned values
+func TestMatchCandidates(t *testing.T) {
+	all := []o7k.NamedResource{
+		{ID: "c3", Name: "web"},
+		{ID: "a1", Name: "Data"},
+		{ID: "b2", Name: "data-backup"},
+		{ID: "d4", Name: "data"},
+	}
+	tests := []struct {
+		name      string
+		value     string
+		byName    bool
+		want      []string
+		wantTotal int
+	}{
+		{"everything", "", false, []string{"a1", "d4", "b2", "c3"}, 4},
+		{"name prefix ignoring case", "DATA", false, []string{"a1", "d4", "b2"}, 3},
+		{"ID prefix", "c", false, []string{"c3"}, 1},
+		{"by name", "dat", true, []string{"Data", "data", "data-backup"}, 3},
+		{"ID prefix by name", "b2", true, []string{"data-backup"}, 1},
+		{"no match", "x", false, []string{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total := matchCandidates(all, tt.value, tt.byName)
+			if !slices.Equal(got, tt.want) || total != tt.wantTotal {
+				t.Errorf("matchCandidates(%q) = %v, %d, want %v, %d", tt.value, got, total, tt.want, tt.wantTotal)
+			}
+		})
+	}
+
+	var many []o7k.NamedResource
+	for i := range MaxCompletionValues + 5 {
+		many = append(many, o7k.NamedResource{ID: fmt.Sprintf("id-%03d", i)})
+	}
+	if got, total := matchCandidates(many, "id-", false); len(got) != MaxCompletionValues || total != len(many) {
+		t.Errorf("matchCandidates() returned %d of %d values, want %d of %d", len(got), total, MaxCompletionValues, len(many))
+	}
+}
+
+// newUnreachableClientSet creates a client set in per-request credentials
+// mode whose cloud cannot be reached
+func newUnreachableClientSet(t *testing.T) *o7k.ClientSet {
+	t.Helper()
+	clients, err := o7k.NewClientSet(&config.OpenStackConfig{
+		AuthURL:      "http://127.0.0.1:1/v3",
+		Region:       "RegionOne",
+		EndpointType: "public",
+		Timeout:      time.Second,
+		VerifySSL:    true,
+	}, nil, true)
+	if err != nil {
+		t.Fatalf("creating client set: %v", err)
+	}
+	return clients
+}
+
+// newTestCompleter creates a completer whose cloud cannot be reached, so
+// that only cached listings complete
+func newTestCompleter(t *testing.T, policy *Policy) *Completer {
+	t.Helper()
+	return NewCompleter(newUnreachableClientSet(t), policy)
+}
+
+// cacheListing caches the volumes a caller with creds lists
+func cacheListing(c *Completer, creds o7k.RequestCredentials, volumes ...o7k.NamedResource) {
+	key := "volume_id\x00\x00\x00" + creds.Key()
+	c.cache[key] = completionCacheEntry{candidates: volumes, expiresAt: time.Now().Add(completionCacheTTL)}
+}
+
+// TestCompleteCacheScope checks that in per-request credentials mode a
+// caller is never completed from the listing of other credentials
+func TestCompleteCacheScope(t *testing.T) {
+	c := newTestCompleter(t, newAccessPolicy(t, config.AccessConfig{}))
+	c.policy.readOnlyTools["volumes_list"] = true
+
+	alice := o7k.RequestCredentials{Token: "alice-token"}
+	bob := o7k.RequestCredentials{Token: "bob-token"}
+	cacheListing(c, alice, o7k.NamedResource{ID: "vol-alice", Name: "alice-data"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"owner", o7k.WithRequestCredentials(context.Background(), alice), []string{"vol-alice"}},
+		{"other credentials", o7k.WithRequestCredentials(context.Background(), bob), nil},
+		{"no credentials", context.Background(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := c.Complete(tt.ctx, "volume_id", "", nil); !slices.Equal(got, tt.want) {
+				t.Errorf("Complete(volume_id) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCompletePolicy checks that arguments complete only for callers
+// permitted to call the tool listing them, and only when it is registered
+func TestCompletePolicy(t *testing.T) {
+	creds := o7k.RequestCredentials{Token: "token"}
+	tests := []struct {
+		name       string
+		roles      []string
+		registered bool
+		want       []string
+	}{
+		{"permitted", []string{"reader"}, true, []string{"vol-1"}},
+		{"role not permitted", []string{"creator"}, true, nil},
+		{"no role", nil, true, nil},
+		{"tool not registered", []string{"reader"}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := newAccessPolicy(t, config.AccessConfig{Roles: map[string][]string{
+				"reader":  {"*_list"},
+				"creator": {"volume_create"},
+			}})
+			if tt.registered {
+				policy.readOnlyTools["volumes_list"] = true
+			}
+			c := newTestCompleter(t, policy)
+			cacheListing(c, creds, o7k.NamedResource{ID: "vol-1", Name: "data"})
+
+			ctx := o7k.WithRequestCredentials(context.Background(), creds)
+			ctx = auth.NewContext(ctx, &auth.Identity{Subject: "alice", Roles: tt.roles})
+			if got, _ := c.Complete(ctx, "volume_id", "vol", nil); !slices.Equal(got, tt.want) {
+				t.Errorf("Complete(volume_id) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// ComputeHandler handles compute (Nova) MCP tool execution requests and delegates to OpenStack client
+type ComputeHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewComputeHandler creates a new compute handler
+func NewComputeHandler(clients *o7k.ClientSet) *ComputeHandler {
+	return &ComputeHandler{
+		clients: clients,
+	}
+}
+
+// HandleListServers handles the servers_list tool
+func (h *ComputeHandler) HandleListServers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing servers_list tool")
+
+	servers, err := h.clients.FromContext(ctx).ListServers(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list servers")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list servers: %v", err)), nil
+	}
+
+	return newListResult(servers, "servers"), nil
+}
+
+// HandleGetServer handles the server_get tool
+func (h *ComputeHandler) HandleGetServer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing server_get tool")
+
+	serverID := request.GetString("server_id", "")
+	if serverID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'server_id' parameter"), nil
+	}
+
+	srv, err := h.clients.FromContext(ctx).GetServer(ctx, serverID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("server_id", serverID).
+			Msg("Failed to get server")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get server: %v", err)), nil
+	}
+
+	return newJSONResult(srv, "server"), nil
+}
+
+// RegisterTools registers all compute-related tools with the MCP server
+func (h *ComputeHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering compute tools")
+
+	registerToolDefinitions(mcpServer, "compute", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// RegisterResources registers the server resources with the MCP server
+func (h *ComputeHandler) RegisterResources(mcpServer *server.MCPServer, policy *Policy) error {
+	registerResourceDefinitions(mcpServer, "compute", []ResourceDefinition{
+		{
+			URI:         ResourceScheme + "servers",
+			Name:        "Servers",
+			Description: "All servers in the current OpenStack project",
+			Tool:        "servers_list",
+			Read: func(ctx context.Context, _ string) (interface{}, error) {
+				return h.clients.FromContext(ctx).ListServers(ctx)
+			},
+		},
+		{
+			URI:         ResourceScheme + "servers/{server_id}",
+			Name:        "Server",
+			Description: "A server by ID",
+			Tool:        "server_get",
+			Read: func(ctx context.Context, id string) (interface{}, error) {
+				return h.clients.FromContext(ctx).GetServer(ctx, id)
+			},
+		},
+	}, policy)
+
+	return nil
+}
+
+// getToolDefinitions returns all compute tool definitions
+func (h *ComputeHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "servers_list",
+			Description: "List all servers in the current OpenStack project",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("servers_list",
+					mcp.WithDescription("List all servers (compute instances) in the current OpenStack project, with their status, flavor, image, IP addresses and attached volumes."),
+					withOutputSchema[listResult[o7k.Server]](),
+				)
+			},
+			Handler: h.HandleListServers,
+		},
+		{
+			Name:        "server_get",
+			Description: "Get details of a specific server by name or ID",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("server_get",
+					mcp.WithDescription("Get detailed information about a server (compute instance) by its name or ID, including status, task and power state, flavor, image, IP addresses, attached volumes and the last fault."),
+					withOutputSchema[o7k.Server](),
+					mcp.WithString("server_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the server"),
+					),
+				)
+			},
+			Handler: h.HandleGetServer,
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// ConfirmTarget describes the resource a tool call acts on, shown to the
+// user when asking for confirmation
+type ConfirmTarget struct {
+	Type   string `json:"type"` // e.g. "volume"
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Size   string `json:"size,omitempty"` // Human-readable, e.g. "10 GB"
+	Status string `json:"status,omitempty"`
+}
+
+// confirmYes is the confirm argument of tools that do not act on a single resource
+const confirmYes = "yes"
+
+// requiresConfirmation reports whether calls of a tool must be confirmed:
+// destructive tools unless disabled, sensitive tools always, and tools the
+// policy flags
+func (p *Policy) requiresConfirmation(toolDef ToolDefinition) bool {
+	return (toolDef.Destructive && p.confirmDestructive) || toolDef.Sensitive || matchAny(p.requireConfirmation, toolDef.Name)
+}
+
+// confirmHandler wraps the handler of a tool requiring confirmation. The
+// user is asked through MCP elicitation, showing the target resource fetched
+// beforehand; clients without elicitation must repeat the resource ID in the
+// confirm argument instead, unless the fallback is to reject the call.
+func confirmHandler(mcpServer *server.MCPServer, policy *Policy, toolDef ToolDefinition) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var target *ConfirmTarget
+		if toolDef.Target != nil {
+			var err error
+			if target, err = toolDef.Target(ctx, request); err != nil {
+				log.Error().
+					Err(err).
+					Str("tool", toolDef.Name).
+					Msg("Failed to look up resource for confirmation")
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to look up the affected resource: %v", err)), nil
+			}
+		}
+		message := confirmationMessage(toolDef, target)
+
+		if supportsElicitation(ctx) {
+			confirmed, err := elicitConfirmation(ctx, mcpServer, message)
+			if err != nil {
+				log.Error().
+					Err(err).
+					Str("tool", toolDef.Name).
+					Msg("Failed to ask for confirmation")
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to ask the user for confirmation: %v", err)), nil
+			}
+			if !confirmed {
+				log.Info().Str("tool", toolDef.Name).Msg("Tool call declined by user")
+				return mcp.NewToolResultError("Cancelled: the user did not confirm the action"), nil
+			}
+			return toolDef.Handler(ctx, request)
+		}
+
+		if policy.confirmationFallback == config.ConfirmationFallbackReject {
+			return mcp.NewToolResultError(fmt.Sprintf("%s requires confirmation, but the client does not support elicitation", toolDef.Name)), nil
+		}
+
+		expected := confirmYes
+		if target != nil {
+			expected = target.ID
+		}
+		if request.GetString("confirm", "") != expected {
+			return mcp.NewToolResultError(fmt.Sprintf("%s Show this to the user and, only once they explicitly agree, call %s again with confirm set to %q.", message, toolDef.Name, expected)), nil
+		}
+		return toolDef.Handler(ctx, request)
+	}
+}
+
+// confirmationMessage describes the pending action and its target
+func confirmationMessage(toolDef ToolDefinition, target *ConfirmTarget) string {
+	message := fmt.Sprintf("Confirm %s: %s.", toolDef.Name, toolDef.Description)
+	if target == nil {
+		return message
+	}
+
+	details := []string{"ID " + target.ID}
+	if target.Size != "" {
+		details = append(details, "size "+target.Size)
+	}
+	if target.Status != "" {
+		details = append(details, "status "+target.Status)
+	}
+	name := target.Name
+	if name == "" {
+		name = "(unnamed)"
+	}
+	return fmt.Sprintf("%s Affected %s: %q (%s).", message, target.Type, name, strings.Join(details, ", "))
+}
+
+// supportsElicitation reports whether the client of the call declared the
+// elicitation capability
+func supportsElicitation(ctx context.Context) bool {
+	session := server.ClientSessionFromContext(ctx)
+	if _, ok := session.(server.SessionWithElicitation); !ok {
+		return false
+	}
+	withInfo, ok := session.(server.SessionWithClientInfo)
+	return ok && withInfo.GetClientCapabilities().Elicitation != nil
+}
+
+// elicitConfirmation asks the user to confirm and reports whether they did
+func elicitConfirmation(ctx context.Context, mcpServer *server.MCPServer, message string) (bool, error) {
+	result, err := mcpServer.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: message,
+			RequestedSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"title":       "Confirm",
+						"description": "Check to proceed with this action",
+					},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+	content, _ := result.Content.(map[string]interface{})
+	confirmed, _ := content["confirm"].(bool)
+	return confirmed, nil
+}
+
+// addConfirmArgument adds the confirm argument of tools requiring
+// confirmation, used by clients without elicitation
+func addConfirmArgument(tool *mcp.Tool, hasTarget bool) {
+	description := fmt.Sprintf("Only for clients without elicitation support: set to %q after the user explicitly confirmed this action", confirmYes)
+	if hasTarget {
+		description = "Only for clients without elicitation support: set to the ID of the affected resource after the user explicitly confirmed this action"
+	}
+	mcp.WithString("confirm", mcp.Description(description))(tool)
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fakeSession is a client session declaring the given capabilities
+type fakeSession struct {
+	capabilities mcp.ClientCapabilities
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *fakeSession) SessionID() string                                   { return "test" }
+func (s *fakeSession) GetClientInfo() mcp.Implementation                   { return mcp.Implementation{} }
+func (s *fakeSession) SetClientInfo(mcp.Implementation)                    {}
+func (s *fakeSession) GetClientCapabilities() mcp.ClientCapabilities       { return s.capabilities }
+func (s *fakeSession) SetClientCapabilities(c mcp.ClientCapabilities)      { s.capabilities = c }
+
+// fakeElicitationSession answers elicitation requests with a fixed response
+type fakeElicitationSession struct {
+	fakeSession
+	response mcp.ElicitationResponse
+	err      error
+	messages []string
+}
+
+func (s *fakeElicitationSession) RequestElicitation(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	s.messages = append(s.messages, request.Params.Message)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &mcp.ElicitationResult{ElicitationResponse: s.response}, nil
+}
+
+// confirmTestTool returns a tool definition acting on a volume, recording
+// whether its handler ran
+func confirmTestTool(called *bool, targetErr error) ToolDefinition {
+	return ToolDefinition{
+		Name:        "volume_delete",
+		Description: "Delete a volume",
+		Destructive: true,
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			*called = true
+			return mcp.NewToolResultText("deleted"), nil
+		},
+		Target: func(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+			if targetErr != nil {
+				return nil, targetErr
+			}
+			return &ConfirmTarget{Type: "volume", ID: "vol-1", Name: "data", Size: "10 GB", Status: "available"}, nil
+		},
+	}
+}
+
+// callConfirmed calls a tool requiring confirmation in a session
+func callConfirmed(t *testing.T, fallback string, session server.ClientSession, toolDef ToolDefinition, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	mcpServer := server.NewMCPServer("test", "0", server.WithElicitation())
+	policy, err := NewPolicy(&config.MCPConfig{Confirmation: config.ConfirmationConfig{Destructive: true, Fallback: fallback}})
+	if err != nil {
+		t.Fatalf("creating policy: %v", err)
+	}
+	if !policy.requiresConfirmation(toolDef) {
+		t.Fatalf("%s does not require confirmation", toolDef.Name)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = toolDef.Name
+	request.Params.Arguments = args
+	result, err := confirmHandler(mcpServer, policy, toolDef)(mcpServer.WithContext(context.Background(), session), request)
+	if err != nil {
+		t.Fatalf("calling %s: %v", toolDef.Name, err)
+	}
+	return result
+}
+
+// TestConfirmElicitation checks that clients declaring elicitation are asked,
+// and that only an accepted, checked confirmation runs the tool
+func TestConfirmElicitation(t *testing.T) {
+	elicitation := mcp.ClientCapabilities{Elicitation: &struct{}{}}
+	tests := []struct {
+		name       string
+		response   mcp.ElicitationResponse
+		err        error
+		wantCalled bool
+	}{
+		{"accepted", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]any{"confirm": true}}, nil, true},
+		{"accepted unchecked", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]any{"confirm": false}}, nil, false},
+		{"accepted without content", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept}, nil, false},
+		{"declined", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}, nil, false},
+		{"cancelled", mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionCancel}, nil, false},
+		{"request failed", mcp.ElicitationResponse{}, errors.New("client went away"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &fakeElicitationSession{fakeSession: fakeSession{capabilities: elicitation}, response: tt.response, err: tt.err}
+			called := false
+			// The confirm argument of the fallback does not bypass elicitation
+			result := callConfirmed(t, config.ConfirmationFallbackArgument, session, confirmTestTool(&called, nil), map[string]any{"volume_id": "vol-1", "confirm": "vol-1"})
+
+			if called != tt.wantCalled || result.IsError == tt.wantCalled {
+				t.Errorf("handler called: %t, error result: %t, want called: %t", called, result.IsError, tt.wantCalled)
+			}
+			if len(session.messages) != 1 {
+				t.Fatalf("elicitation requests = %d, want 1", len(session.messages))
+			}
+			if !strings.Contains(session.messages[0], `"data"`) || !strings.Contains(session.messages[0], "ID vol-1") {
+				t.Errorf("message %q does not describe the target volume", session.messages[0])
+			}
+		})
+	}
+}
+
+// TestConfirmFallback checks the confirm argument of clients without
+// elicitation support, including sessions able to elicit that did not
+// declare the capability
+func TestConfirmFallback(t *testing.T) {
+	sessions := map[string]func() server.ClientSession{
+		"no elicitation":          func() server.ClientSession { return &fakeSession{} },
+		"capability not declared": func() server.ClientSession { return &fakeElicitationSession{} },
+	}
+	tests := []struct {
+		name       string
+		fallback   string
+		confirm    any
+		target     bool
+		wantCalled bool
+	}{
+		{"target ID", config.ConfirmationFallbackArgument, "vol-1", true, true},
+		{"wrong ID", config.ConfirmationFallbackArgument, "vol-2", true, false},
+		{"yes instead of ID", config.ConfirmationFallbackArgument, confirmYes, true, false},
+		{"left out", config.ConfirmationFallbackArgument, nil, true, false},
+		{"yes without target", config.ConfirmationFallbackArgument, confirmYes, false, true},
+		{"ID without target", config.ConfirmationFallbackArgument, "vol-1", false, false},
+		{"reject", config.ConfirmationFallbackReject, "vol-1", true, false},
+	}
+	for sessionName, newSession := range sessions {
+		for _, tt := range tests {
+			t.Run(sessionName+"/"+tt.name, func(t *testing.T) {
+				called := false
+				toolDef := confirmTestTool(&called, nil)
+				if !tt.target {
+					toolDef.Target = nil
+				}
+				args := map[string]any{"volume_id": "vol-1"}
+				if tt.confirm != nil {
+					args["confirm"] = tt.confirm
+				}
+				session := newSession()
+				result := callConfirmed(t, tt.fallback, session, toolDef, args)
+
+				if called != tt.wantCalled || result.IsError == tt.wantCalled {
+					t.Errorf("handler called: %t, error result: %t, want called: %t", called, result.IsError, tt.wantCalled)
+				}
+				if elicited, ok := session.(*fakeElicitationSession); ok && len(elicited.messages) > 0 {
+					t.Error("elicitation requested from a client that did not declare it")
+				}
+			})
+		}
+	}
+}
+
+// TestConfirmTargetLookupFailure checks that a call whose target cannot be
+// looked up is rejected before asking the user
+func TestConfirmTargetLookupFailure(t *testing.T) {
+	session := &fakeElicitationSession{
+		fakeSession: fakeSession{capabilities: mcp.ClientCapabilities{Elicitation: &struct{}{}}},
+		response:    mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]any{"confirm": true}},
+	}
+	called := false
+	result := callConfirmed(t, config.ConfirmationFallbackArgument, session, confirmTestTool(&called, errors.New("volume not found")), map[string]any{"volume_id": "vol-1"})
+
+	if called || !result.IsError {
+		t.Errorf("handler called: %t, error result: %t, want rejected", called, result.IsError)
+	}
+	if len(session.messages) != 0 {
+		t.Error("user asked to confirm an action on a resource that could not be looked up")
+	}
+}
@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// ContainerInfraHandler handles container infrastructure (Magnum) MCP tool execution requests and delegates to OpenStack client
+type ContainerInfraHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewContainerInfraHandler creates a new container infra handler
+func NewContainerInfraHandler(clients *o7k.ClientSet) *ContainerInfraHandler {
+	return &ContainerInfraHandler{
+		clients: clients,
+	}
+}
+
+// HandleListClusterTemplates handles the coe_cluster_templates_list tool
+func (h *ContainerInfraHandler) HandleListClusterTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_cluster_templates_list tool")
+
+	templates, err := h.clients.FromContext(ctx).ListClusterTemplates(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list cluster templates")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list cluster templates: %v", err)), nil
+	}
+
+	return newListResult(templates, "cluster templates"), nil
+}
+
+// HandleGetClusterTemplate handles the coe_cluster_template_get tool
+func (h *ContainerInfraHandler) HandleGetClusterTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_cluster_template_get tool")
+
+	templateID := request.GetString("cluster_template_id", "")
+	if templateID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'cluster_template_id' parameter"), nil
+	}
+
+	template, err := h.clients.FromContext(ctx).GetClusterTemplate(ctx, templateID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("cluster_template_id", templateID).
+			Msg("Failed to get cluster template")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get cluster template: %v", err)), nil
+	}
+
+	return newJSONResult(template, "cluster template"), nil
+}
+
+// HandleListClusters handles the coe_clusters_list tool
+func (h *ContainerInfraHandler) HandleListClusters(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_clusters_list tool")
+
+	clusters, err := h.clients.FromContext(ctx).ListClusters(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list clusters")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list clusters: %v", err)), nil
+	}
+
+	return newListResult(clusters, "clusters"), nil
+}
+
+// HandleGetCluster handles the coe_cluster_get tool
+func (h *ContainerInfraHandler) HandleGetCluster(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_cluster_get tool")
+
+	clusterID := request.GetString("cluster_id", "")
+	if clusterID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'cluster_id' parameter"), nil
+	}
+
+	cluster, err := h.clients.FromContext(ctx).GetCluster(ctx, clusterID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("cluster_id", clusterID).
+			Msg("Failed to get cluster")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get cluster: %v", err)), nil
+	}
+
+	return newJSONResult(cluster, "cluster"), nil
+}
+
+// HandleGetClusterHealth handles the coe_cluster_health tool
+func (h *ContainerInfraHandler) HandleGetClusterHealth(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_cluster_health tool")
+
+	clusterID := request.GetString("cluster_id", "")
+	if clusterID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'cluster_id' parameter"), nil
+	}
+
+	health, err := h.clients.FromContext(ctx).GetClusterHealth(ctx, clusterID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("cluster_id", clusterID).
+			Msg("Failed to get cluster health")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get cluster health: %v", err)), nil
+	}
+
+	return newJSONResult(health, "cluster health"), nil
+}
+
+// NodeGroupResizeArgs defines the arguments for resizing a node group
+type NodeGroupResizeArgs struct {
+	ClusterID string `json:"cluster_id"`
+	NodeGroup string `json:"node_group"`
+	NodeCount *int   `json:"node_count"` // Required; a missing count must not scale to zero
+}
+
+// NodeGroupResizeResult is returned by the coe_nodegroup_resize tool
+type NodeGroupResizeResult struct {
+	Success   bool   `json:"success"`
+	ClusterID string `json:"cluster_id"`
+	NodeGroup string `json:"node_group"`
+	NodeCount int    `json:"node_count"`
+	Message   string `json:"message"`
+}
+
+// HandleResizeNodeGroup handles the coe_nodegroup_resize tool
+func (h *ContainerInfraHandler) HandleResizeNodeGroup(ctx context.Context, request mcp.CallToolRequest, args NodeGroupResizeArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_nodegroup_resize tool")
+
+	if args.ClusterID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'cluster_id' parameter"), nil
+	}
+	if args.NodeGroup == "" {
+		return mcp.NewToolResultError("Missing or invalid 'node_group' parameter"), nil
+	}
+	if args.NodeCount == nil {
+		return mcp.NewToolResultError("Missing or invalid 'node_count' parameter"), nil
+	}
+	if *args.NodeCount < 0 {
+		return mcp.NewToolResultError("Node count must not be negative"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).ResizeNodeGroup(ctx, args.ClusterID, args.NodeGroup, *args.NodeCount); err != nil {
+		log.Error().
+			Err(err).
+			Str("cluster_id", args.ClusterID).
+			Str("node_group", args.NodeGroup).
+			Msg("Failed to resize node group")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resize node group: %v", err)), nil
+	}
+
+	result := NodeGroupResizeResult{
+		Success:   true,
+		ClusterID: args.ClusterID,
+		NodeGroup: args.NodeGroup,
+		NodeCount: *args.NodeCount,
+		Message:   "Node group resize started",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// HandleGetClusterCA handles the coe_cluster_ca_get tool
+func (h *ContainerInfraHandler) HandleGetClusterCA(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_cluster_ca_get tool")
+
+	clusterID := request.GetString("cluster_id", "")
+	if clusterID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'cluster_id' parameter"), nil
+	}
+
+	ca, err := h.clients.FromContext(ctx).GetClusterCA(ctx, clusterID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("cluster_id", clusterID).
+			Msg("Failed to get cluster CA")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get cluster CA: %v", err)), nil
+	}
+
+	return newJSONResult(ca, "cluster CA"), nil
+}
+
+// HandleCreateKubeconfig handles the coe_cluster_kubeconfig tool
+func (h *ContainerInfraHandler) HandleCreateKubeconfig(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing coe_cluster_kubeconfig tool")
+
+	clusterID := request.GetString("cluster_id", "")
+	if clusterID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'cluster_id' parameter"), nil
+	}
+
+	creds, err := h.clients.FromContext(ctx).CreateClusterCredentials(ctx, clusterID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("cluster_id", clusterID).
+			Msg("Failed to create cluster kubeconfig")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create cluster kubeconfig: %v", err)), nil
+	}
+
+	log.Info().
+		Str("cluster_id", creds.ClusterID).
+		Msg("Cluster kubeconfig issued")
+
+	return newJSONResult(creds, "cluster credentials"), nil
+}
+
+// RegisterTools registers all container infra tools with the MCP server
+func (h *ContainerInfraHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering container infra tools")
+
+	registerToolDefinitions(mcpServer, "containerinfra", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// RegisterResources registers the cluster resources with the MCP server
+func (h *ContainerInfraHandler) RegisterResources(mcpServer *server.MCPServer, policy *Policy) error {
+	registerResourceDefinitions(mcpServer, "containerinfra", []ResourceDefinition{
+		{
+			URI:         ResourceScheme + "coe/clusters",
+			Name:        "Clusters",
+			Description: "All Magnum clusters in the current OpenStack project",
+			Tool:        "coe_clusters_list",
+			Read: func(ctx context.Context, _ string) (interface{}, error) {
+				return h.clients.FromContext(ctx).ListClusters(ctx)
+			},
+		},
+		{
+			URI:         ResourceScheme + "coe/clusters/{cluster_id}",
+			Name:        "Cluster",
+			Description: "A Magnum cluster by UUID or name",
+			Tool:        "coe_cluster_get",
+			Read: func(ctx context.Context, id string) (interface{}, error) {
+				return h.clients.FromContext(ctx).GetCluster(ctx, id)
+			},
+		},
+	}, policy)
+
+	return nil
+}
+
+// clusterTarget looks up the cluster a kubeconfig is issued for, for
+// confirmation
+func (h *ContainerInfraHandler) clusterTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	cluster, err := h.clients.FromContext(ctx).GetCluster(ctx, request.GetString("cluster_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmTarget{
+		Type:   "cluster",
+		ID:     cluster.ID,
+		Name:   cluster.Name,
+		Size:   fmt.Sprintf("%d masters, %d nodes", cluster.MasterCount, cluster.NodeCount),
+		Status: cluster.Status,
+	}, nil
+}
+
+// nodeGroupTarget looks up the node group of a resize for confirmation,
+// showing its current and requested node counts
+func (h *ContainerInfraHandler) nodeGroupTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	nodeCount, err := request.RequireInt("node_count")
+	if err != nil {
+		return nil, err
+	}
+
+	client := h.clients.FromContext(ctx)
+	cluster, err := client.GetCluster(ctx, request.GetString("cluster_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	groups, err := client.ListNodeGroups(ctx, cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := request.GetString("node_group", "")
+	for _, group := range groups {
+		if group.ID != name && group.Name != name {
+			continue
+		}
+		return &ConfirmTarget{
+			Type:   "node group",
+			ID:     group.ID,
+			Name:   fmt.Sprintf("%s of cluster %s", group.Name, cluster.Name),
+			Size:   fmt.Sprintf("%d nodes, to be resized to %d", group.NodeCount, nodeCount),
+			Status: group.Status,
+		}, nil
+	}
+	return nil, fmt.Errorf("cluster %s has no node group %q", cluster.Name, name)
+}
+
+// getToolDefinitions returns all container infra tool definitions
+func (h *ContainerInfraHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "coe_cluster_templates_list",
+			Description: "List Magnum cluster templates",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_cluster_templates_list",
+					mcp.WithDescription("List container infrastructure (Magnum) cluster templates visible to the current project, with COE type, image, flavors and labels."),
+					withOutputSchema[listResult[o7k.ClusterTemplate]](),
+				)
+			},
+			Handler: h.HandleListClusterTemplates,
+		},
+		{
+			Name:        "coe_cluster_template_get",
+			Description: "Get details of a Magnum cluster template",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_cluster_template_get",
+					mcp.WithDescription("Get detailed information about a Magnum cluster template."),
+					withOutputSchema[o7k.ClusterTemplate](),
+					mcp.WithString("cluster_template_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the cluster template"),
+					),
+				)
+			},
+			Handler: h.HandleGetClusterTemplate,
+		},
+		{
+			Name:        "coe_clusters_list",
+			Description: "List Magnum clusters",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_clusters_list",
+					mcp.WithDescription("List Magnum clusters in the current project with status, health, API address and node counts."),
+					withOutputSchema[listResult[o7k.Cluster]](),
+				)
+			},
+			Handler: h.HandleListClusters,
+		},
+		{
+			Name:        "coe_cluster_get",
+			Description: "Get details of a Magnum cluster",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_cluster_get",
+					mcp.WithDescription("Get detailed information about a Magnum cluster, including status reason, faults and node addresses."),
+					withOutputSchema[o7k.Cluster](),
+					mcp.WithString("cluster_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the cluster"),
+					),
+				)
+			},
+			Handler: h.HandleGetCluster,
+		},
+		{
+			Name:        "coe_cluster_health",
+			Description: "Show the health and node counts of a Magnum cluster",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_cluster_health",
+					mcp.WithDescription("Show a Magnum cluster's status, health status and reasons, and the node count of each node group."),
+					withOutputSchema[o7k.ClusterHealth](),
+					mcp.WithString("cluster_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the cluster"),
+					),
+				)
+			},
+			Handler: h.HandleGetClusterHealth,
+		},
+		{
+			Name:        "coe_nodegroup_resize",
+			Description: "Resize a node group of a Magnum cluster",
+			ReadOnly:    false,
+			Destructive: true,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_nodegroup_resize",
+					mcp.WithDescription("Change the number of nodes in a Magnum cluster node group. Scaling down removes nodes and the workloads running on them."),
+					withOutputSchema[NodeGroupResizeResult](),
+					mcp.WithString("cluster_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the cluster"),
+					),
+					mcp.WithString("node_group",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the node group (e.g., 'default-worker')"),
+					),
+					mcp.WithNumber("node_count",
+						mcp.Required(),
+						mcp.Description("Desired number of nodes in the node group"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleResizeNodeGroup),
+			Target:  h.nodeGroupTarget,
+		},
+		{
+			Name:        "coe_cluster_ca_get",
+			Description: "Get the CA certificate and API address of a Magnum cluster",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_cluster_ca_get",
+					mcp.WithDescription("Get the PEM encoded CA certificate and API server address needed to trust a Magnum cluster's API."),
+					withOutputSchema[o7k.ClusterCA](),
+					mcp.WithString("cluster_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the cluster"),
+					),
+				)
+			},
+			Handler: h.HandleGetClusterCA,
+		},
+		{
+			Name:        "coe_cluster_kubeconfig",
+			Description: "Issue a kubeconfig for a Magnum Kubernetes cluster",
+			ReadOnly:    false,
+			Sensitive:   true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("coe_cluster_kubeconfig",
+					mcp.WithDescription("Generate a client key, have Magnum sign an admin client certificate for it, and return a kubeconfig for the cluster. The kubeconfig grants cluster-admin access; handle it as a secret."),
+					withOutputSchema[o7k.ClusterCredentials](),
+					mcp.WithString("cluster_id",
+						mcp.Required(),
+						mcp.Description("The UUID or name of the cluster"),
+					),
+				)
+			},
+			Handler: h.HandleCreateKubeconfig,
+			Target:  h.clusterTarget,
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// DryRunResult is returned by write tools in a dry run
+type DryRunResult struct {
+	DryRun   bool                `json:"dry_run"`
+	Tool     string              `json:"tool"`
+	Target   *ConfirmTarget      `json:"target,omitempty"` // Resolved resource the call acts on
+	Requests []o7k.DryRunRequest `json:"requests"`
+	Message  string              `json:"message"`
+}
+
+// isDryRun reports whether a call of a write tool is a dry run, because the
+// server runs in dry-run mode or the call sets dry_run
+func (p *Policy) isDryRun(tool string, request mcp.CallToolRequest) bool {
+	if p.readOnlyTools[tool] {
+		return false
+	}
+	return p.DryRun || request.GetBool("dry_run", false)
+}
+
+// dryRunHandler wraps the handler of a write tool. Dry runs validate the
+// arguments and resolve the resources they reference as usual, but return
+// the OpenStack requests the tool would send instead of sending them; no
+// confirmation is asked since nothing changes.
+func dryRunHandler(policy *Policy, toolDef ToolDefinition, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !policy.isDryRun(toolDef.Name, request) {
+			return next(ctx, request)
+		}
+
+		ctx, dryRun := o7k.WithDryRun(ctx)
+		result := DryRunResult{DryRun: true, Tool: toolDef.Name}
+		if toolDef.Target != nil {
+			target, err := toolDef.Target(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to look up the affected resource: %v", err)), nil
+			}
+			result.Target = target
+		}
+
+		handlerResult, err := toolDef.Handler(ctx, request)
+		result.Requests = dryRun.Requests()
+		if len(result.Requests) == 0 {
+			// Rejected before any request, e.g. on invalid arguments
+			if err != nil || (handlerResult != nil && handlerResult.IsError) {
+				return handlerResult, err
+			}
+			result.Message = "Dry run: the call would not send any request"
+			return newJSONResult(result, "dry run result"), nil
+		}
+
+		// The tool stops at its first write, as its response is unknown
+		result.Message = "Dry run: nothing was sent. Requests the tool would make after this one depend on its response and are not shown."
+		log.Info().
+			Str("tool", toolDef.Name).
+			Int("requests", len(result.Requests)).
+			Msg("Dry run completed")
+		return newJSONResult(result, "dry run result"), nil
+	}
+}
+
+// addDryRunArgument adds the dry_run argument of write tools
+func addDryRunArgument(tool *mcp.Tool) {
+	mcp.WithBoolean("dry_run",
+		mcp.Description("Validate the call and return the OpenStack request it would send, without sending it"),
+	)(tool)
+}
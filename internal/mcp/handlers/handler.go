@@ -2,14 +2,17 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
 )
 
 // Handler defines the interface for registering MCP tools
 type Handler interface {
-	RegisterTools(mcpServer *server.MCPServer, readOnly bool) error
+	RegisterTools(mcpServer *server.MCPServer, policy *Policy) error
 }
 
 // ToolDefinition defines a single MCP tool with its metadata and handler
@@ -17,6 +20,108 @@ type ToolDefinition struct {
 	Name        string
 	Description string
 	ReadOnly    bool // If true, tool is available even in read-only mode
+	Destructive bool // If true, calls may destroy data or disrupt workloads and are confirmed by the user first
+	Sensitive   bool // If true, calls hand out credentials; the tool is only exposed when the policy enables it, and always confirmed
+	Idempotent  bool // If true, repeating a call with the same arguments has no further effect
+	OpenWorld   bool // If true, the tool calls OpenStack rather than only reading local state
 	BuildTool   func() mcp.Tool
 	Handler     func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Target looks up the resource a call acts on, shown when asking for
+	// confirmation. Optional.
+	Target func(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error)
+}
+
+// registerToolDefinitions adds the given tools to the MCP server, skipping
+// write tools when read-only mode is enabled and tools the policy excludes
+func registerToolDefinitions(mcpServer *server.MCPServer, group string, tools []ToolDefinition, policy *Policy) {
+	registeredCount := 0
+	skippedCount := 0
+
+	for _, toolDef := range tools {
+		// Skip write tools in read-only mode and tools excluded by the policy
+		if ok, reason := policy.allows(toolDef); !ok {
+			log.Debug().
+				Str("tool", toolDef.Name).
+				Msgf("Skipping tool (%s)", reason)
+			skippedCount++
+			continue
+		}
+
+		// Build and register the tool
+		tool := buildTool(toolDef)
+		addCloudArguments(&tool)
+		handler := server.ToolHandlerFunc(toolDef.Handler)
+		if policy.requiresConfirmation(toolDef) {
+			addConfirmArgument(&tool, toolDef.Target != nil)
+			handler = confirmHandler(mcpServer, policy, toolDef)
+		}
+		if !toolDef.ReadOnly {
+			addDryRunArgument(&tool)
+			handler = dryRunHandler(policy, toolDef, handler)
+		}
+		policy.readOnlyTools[toolDef.Name] = toolDef.ReadOnly
+		mcpServer.AddTool(tool, handler)
+
+		log.Debug().
+			Str("tool", toolDef.Name).
+			Bool("read_only", toolDef.ReadOnly).
+			Msg("Tool registered")
+		registeredCount++
+	}
+
+	log.Info().
+		Str("group", group).
+		Int("registered", registeredCount).
+		Int("skipped", skippedCount).
+		Msg("Tools registration complete")
+}
+
+// buildTool builds the MCP tool of a definition, annotated with its
+// behavior so clients can decide which calls to approve automatically
+func buildTool(toolDef ToolDefinition) mcp.Tool {
+	tool := toolDef.BuildTool()
+	tool.Annotations = mcp.ToolAnnotation{
+		Title:        tool.Annotations.Title,
+		ReadOnlyHint: mcp.ToBoolPtr(toolDef.ReadOnly),
+		// Credentials handed out cannot be taken back, so clients should
+		// not approve sensitive calls automatically either
+		DestructiveHint: mcp.ToBoolPtr(toolDef.Destructive || toolDef.Sensitive),
+		// Reads have no effect to repeat
+		IdempotentHint: mcp.ToBoolPtr(toolDef.ReadOnly || toolDef.Idempotent),
+		OpenWorldHint:  mcp.ToBoolPtr(toolDef.OpenWorld),
+	}
+	if !toolDef.ReadOnly && tool.RawOutputSchema != nil {
+		// Dry runs return a DryRunResult instead
+		tool.RawOutputSchema = anyOfSchema(tool.RawOutputSchema, dryRunOutputSchema)
+	}
+	return tool
+}
+
+// listResult is the structured content of list tools, which MCP requires
+// to be an object
+type listResult[T any] struct {
+	Items []T `json:"items"`
+}
+
+// newListResult returns items as the structured content of a tool result,
+// see newJSONResult
+func newListResult[T any](items []T, what string) *mcp.CallToolResult {
+	if items == nil {
+		items = []T{}
+	}
+	return newJSONResult(listResult[T]{Items: items}, what)
+}
+
+// newJSONResult returns v as the structured content of a tool result, with
+// its JSON as the text fallback for clients without structured content
+// support. Returns an error result naming what could not be marshaled on
+// failure.
+func newJSONResult(v interface{}, what string) *mcp.CallToolResult {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal %s", what)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal %s: %v", what, err))
+	}
+	return mcp.NewToolResultStructured(v, string(data))
 }
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// NetworkHandler handles network (Neutron) MCP tool execution requests and delegates to OpenStack client
+type NetworkHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewNetworkHandler creates a new network handler
+func NewNetworkHandler(clients *o7k.ClientSet) *NetworkHandler {
+	return &NetworkHandler{
+		clients: clients,
+	}
+}
+
+// HandleListNetworks handles the networks_list tool
+func (h *NetworkHandler) HandleListNetworks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing networks_list tool")
+
+	networks, err := h.clients.FromContext(ctx).ListNetworks(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list networks")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list networks: %v", err)), nil
+	}
+
+	return newListResult(networks, "networks"), nil
+}
+
+// RegisterTools registers all network-related tools with the MCP server
+func (h *NetworkHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering network tools")
+
+	registerToolDefinitions(mcpServer, "network", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// getToolDefinitions returns all network tool definitions
+func (h *NetworkHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "networks_list",
+			Description: "List the networks visible to the current OpenStack project",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("networks_list",
+					mcp.WithDescription("List the networks visible to the current OpenStack project, including shared and external ones, with their status and subnets."),
+					withOutputSchema[listResult[o7k.Network]](),
+				)
+			},
+			Handler: h.HandleListNetworks,
+		},
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// PlacementHandler handles Placement MCP tool execution requests and delegates to OpenStack client
+type PlacementHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewPlacementHandler creates a new placement handler
+func NewPlacementHandler(clients *o7k.ClientSet) *PlacementHandler {
+	return &PlacementHandler{
+		clients: clients,
+	}
+}
+
+// ResourceProvidersListArgs defines the filters for listing resource providers
+type ResourceProvidersListArgs struct {
+	Name      string `json:"name,omitempty"`
+	InTree    string `json:"in_tree,omitempty"`
+	Resources string `json:"resources,omitempty"`
+	Required  string `json:"required,omitempty"`
+}
+
+// HandleListResourceProviders handles the placement_resource_providers_list tool
+func (h *PlacementHandler) HandleListResourceProviders(ctx context.Context, request mcp.CallToolRequest, args ResourceProvidersListArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing placement_resource_providers_list tool")
+
+	opts := o7k.ListResourceProvidersOpts{
+		Name:      args.Name,
+		InTree:    args.InTree,
+		Resources: args.Resources,
+		Required:  args.Required,
+	}
+
+	providers, err := h.clients.FromContext(ctx).ListResourceProviders(ctx, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list resource providers")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list resource providers: %v", err)), nil
+	}
+
+	return newListResult(providers, "resource providers"), nil
+}
+
+// HandleGetInventory handles the placement_resource_provider_inventory tool
+func (h *PlacementHandler) HandleGetInventory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing placement_resource_provider_inventory tool")
+
+	providerID := request.GetString("provider_id", "")
+	if providerID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'provider_id' parameter"), nil
+	}
+
+	inventory, err := h.clients.FromContext(ctx).GetResourceProviderInventory(ctx, providerID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("provider_id", providerID).
+			Msg("Failed to get resource provider inventory")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get resource provider inventory: %v", err)), nil
+	}
+
+	return newJSONResult(inventory, "inventory"), nil
+}
+
+// ResourceProviderTraits is returned by the placement_resource_provider_traits tool
+type ResourceProviderTraits struct {
+	ProviderID string   `json:"provider_id"`
+	Traits     []string `json:"traits"`
+}
+
+// HandleGetTraits handles the placement_resource_provider_traits tool
+func (h *PlacementHandler) HandleGetTraits(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing placement_resource_provider_traits tool")
+
+	providerID := request.GetString("provider_id", "")
+	if providerID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'provider_id' parameter"), nil
+	}
+
+	traits, err := h.clients.FromContext(ctx).GetResourceProviderTraits(ctx, providerID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("provider_id", providerID).
+			Msg("Failed to get resource provider traits")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get resource provider traits: %v", err)), nil
+	}
+
+	result := ResourceProviderTraits{
+		ProviderID: providerID,
+		Traits:     traits,
+	}
+
+	return newJSONResult(result, "traits"), nil
+}
+
+// HandleGetConsumerAllocations handles the placement_allocations_get tool
+func (h *PlacementHandler) HandleGetConsumerAllocations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing placement_allocations_get tool")
+
+	consumerID := request.GetString("consumer_id", "")
+	if consumerID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'consumer_id' parameter"), nil
+	}
+
+	allocations, err := h.clients.FromContext(ctx).GetConsumerAllocations(ctx, consumerID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("consumer_id", consumerID).
+			Msg("Failed to get consumer allocations")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get consumer allocations: %v", err)), nil
+	}
+
+	return newJSONResult(allocations, "allocations"), nil
+}
+
+// RegisterTools registers all placement tools with the MCP server
+func (h *PlacementHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering placement tools")
+
+	registerToolDefinitions(mcpServer, "placement", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// getToolDefinitions returns all placement tool definitions
+func (h *PlacementHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "placement_resource_providers_list",
+			Description: "List Placement resource providers",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("placement_resource_providers_list",
+					mcp.WithDescription("List Placement resource providers (compute nodes, shared storage, nested providers). Use the 'resources' and 'required' filters to find providers able to satisfy a request when Nova reports 'No valid host'."),
+					withOutputSchema[listResult[o7k.ResourceProvider]](),
+					mcp.WithString("name",
+						mcp.Description("Optional exact provider name, usually the compute hypervisor hostname"),
+					),
+					mcp.WithString("in_tree",
+						mcp.Description("Optional provider UUID; only providers in the same tree are returned"),
+					),
+					mcp.WithString("resources",
+						mcp.Description("Optional capacity filter, e.g. 'VCPU:4,MEMORY_MB:8192,DISK_GB:80'"),
+					),
+					mcp.WithString("required",
+						mcp.Description("Optional comma separated traits the provider must have; prefix a trait with '!' to forbid it"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleListResourceProviders),
+		},
+		{
+			Name:        "placement_resource_provider_inventory",
+			Description: "Show inventories and usages of a resource provider",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("placement_resource_provider_inventory",
+					mcp.WithDescription("Show the inventory of each resource class of a provider together with its usage: total, reserved, allocation ratio, min/max unit, effective capacity, used and free."),
+					withOutputSchema[o7k.ResourceProviderInventory](),
+					mcp.WithString("provider_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the resource provider"),
+					),
+				)
+			},
+			Handler: h.HandleGetInventory,
+		},
+		{
+			Name:        "placement_resource_provider_traits",
+			Description: "List the traits of a resource provider",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("placement_resource_provider_traits",
+					mcp.WithDescription("List the traits (e.g. HW_CPU_X86_AVX2, COMPUTE_STATUS_DISABLED) of a resource provider."),
+					withOutputSchema[ResourceProviderTraits](),
+					mcp.WithString("provider_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the resource provider"),
+					),
+				)
+			},
+			Handler: h.HandleGetTraits,
+		},
+		{
+			Name:        "placement_allocations_get",
+			Description: "Show the allocations of a consumer",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("placement_allocations_get",
+					mcp.WithDescription("Show the resources a consumer holds on each resource provider. For instances the consumer ID is the server UUID."),
+					withOutputSchema[o7k.ConsumerAllocations](),
+					mcp.WithString("consumer_id",
+						mcp.Required(),
+						mcp.Description("The UUID of the consumer, usually a server UUID, or the name of a server"),
+					),
+				)
+			},
+			Handler: h.HandleGetConsumerAllocations,
+		},
+	}
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// Policy decides which tools are registered and checks every tool call
+// against the configured argument constraints and confirmation flags
+type Policy struct {
+	ReadOnly bool
+	DryRun   bool
+
+	allow               []string
+	deny                []string
+	constraints         []config.ToolConstraint
+	requireConfirmation []string
+	allowSensitive      []string
+
+	confirmDestructive   bool
+	confirmationFallback string
+
+	roles         map[string][]string // Lower-cased role name to tool patterns
+	defaultTools  []string
+	readOnlyTools map[string]bool // Filled as tools are registered
+}
+
+// NewPolicy creates the tool policy of the MCP configuration
+func NewPolicy(cfg *config.MCPConfig) (*Policy, error) {
+	p := &Policy{
+		ReadOnly:             cfg.ReadOnly,
+		DryRun:               cfg.DryRun,
+		allow:                cfg.Policy.Allow,
+		deny:                 cfg.Policy.Deny,
+		requireConfirmation:  cfg.Policy.RequireConfirmation,
+		allowSensitive:       cfg.Policy.AllowSensitive,
+		confirmDestructive:   cfg.Confirmation.Destructive,
+		confirmationFallback: cfg.Confirmation.Fallback,
+		roles:                map[string][]string{},
+		defaultTools:         cfg.Access.DefaultTools,
+		readOnlyTools:        map[string]bool{},
+	}
+	// Role names are matched case-insensitively; roles differing only in
+	// case share their tools
+	for role, tools := range cfg.Access.Roles {
+		role = strings.ToLower(role)
+		p.roles[role] = append(p.roles[role], tools...)
+	}
+	for _, s := range cfg.Policy.Constraints {
+		constraint, err := config.ParseToolConstraint(s)
+		if err != nil {
+			return nil, err
+		}
+		p.constraints = append(p.constraints, constraint)
+	}
+	return p, nil
+}
+
+// allows reports whether a tool is exposed: write tools are hidden in
+// read-only mode, denied tools always, sensitive tools unless enabled and,
+// with an allow list, unlisted ones
+func (p *Policy) allows(toolDef ToolDefinition) (bool, string) {
+	if p.ReadOnly && !toolDef.ReadOnly {
+		return false, "read-only mode enabled"
+	}
+	if matchAny(p.deny, toolDef.Name) {
+		return false, "denied by policy"
+	}
+	if toolDef.Sensitive && !matchAny(p.allowSensitive, toolDef.Name) {
+		return false, "sensitive tool not enabled by policy"
+	}
+	if len(p.allow) > 0 && !matchAny(p.allow, toolDef.Name) {
+		return false, "not allowed by policy"
+	}
+	return true, ""
+}
+
+// Permits reports whether the caller may see and call a tool. Without a
+// role mapping every caller may; with one, callers get the tools of their
+// roles, or the default tools when none of their roles is mapped.
+func (p *Policy) Permits(identity *auth.Identity, tool string) bool {
+	if len(p.roles) == 0 {
+		return true
+	}
+	if identity == nil {
+		return false
+	}
+
+	var patterns []string
+	for _, role := range identity.Roles {
+		patterns = append(patterns, p.roles[strings.ToLower(role)]...)
+	}
+	if patterns == nil {
+		patterns = p.defaultTools
+	}
+
+	for _, pattern := range patterns {
+		if pattern == config.AccessReadOnlyTools {
+			if p.readOnlyTools[tool] {
+				return true
+			}
+			continue
+		}
+		if match(pattern, tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolFilter hides the tools a caller may not call from tools/list
+func ToolFilter(p *Policy) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		identity := auth.FromContext(ctx)
+		permitted := make([]mcp.Tool, 0, len(tools))
+		for _, tool := range tools {
+			if p.Permits(identity, tool.Name) {
+				permitted = append(permitted, tool)
+			}
+		}
+		return permitted
+	}
+}
+
+// Check validates the arguments of a tool call against the constraints. An
+// argument a constraint names must be given unless the constraint only
+// excludes values: left out, the tool would use a default the constraint
+// cannot check.
+func (p *Policy) Check(tool string, args map[string]any) error {
+	return p.check(tool, args, nil)
+}
+
+// check validates the arguments of a tool call against the constraints,
+// except those on the skipped arguments
+func (p *Policy) check(tool string, args map[string]any, skip map[string]o7k.ResourceKind) error {
+	for _, c := range p.constraints {
+		if !match(c.Tool, tool) {
+			continue
+		}
+		if _, ok := skip[c.Argument]; ok {
+			continue
+		}
+		value, ok := args[c.Argument]
+		if !ok || value == nil || value == "" {
+			if !c.RequiresArgument() {
+				continue
+			}
+			return fmt.Errorf("argument %s is required by policy constraint %s %s %v", c.Argument, c.Argument, c.Operator, formatValues(c))
+		}
+		if !c.Satisfied(value) {
+			return fmt.Errorf("argument %s=%s violates policy constraint %s %s %v", c.Argument, config.FormatValue(value), c.Argument, c.Operator, formatValues(c))
+		}
+	}
+	return nil
+}
+
+// PolicyMiddleware enforces the role-based access and argument constraints
+// of the policy for every tool call. Constraints on arguments that may name
+// a resource are left to ResolveMiddleware, which checks the IDs the names
+// resolve to. Confirmation is asked by the handlers of the tools requiring
+// it, once the target resource can be looked up.
+func PolicyMiddleware(p *Policy) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool := request.Params.Name
+
+			if identity := auth.FromContext(ctx); !p.Permits(identity, tool) {
+				event := log.Warn().Str("tool", tool)
+				if identity != nil {
+					event = event.Str("subject", identity.Subject).Strs("roles", identity.Roles)
+				}
+				event.Msg("Tool call not permitted for caller")
+				return mcp.NewToolResultError(fmt.Sprintf("You are not permitted to call %s", tool)), nil
+			}
+
+			if err := p.check(tool, request.GetArguments(), resolvedArguments); err != nil {
+				log.Warn().
+					Err(err).
+					Str("tool", tool).
+					Msg("Tool call rejected by policy")
+				return mcp.NewToolResultError(fmt.Sprintf("Rejected by policy: %v", err)), nil
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// matchAny reports whether a tool name matches any of the patterns
+func matchAny(patterns []string, tool string) bool {
+	for _, pattern := range patterns {
+		if match(pattern, tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether a tool name matches a name or glob; patterns are
+// validated with the configuration
+func match(pattern, tool string) bool {
+	ok, _ := path.Match(pattern, tool)
+	return ok
+}
+
+// formatValues renders the constraint values for error messages
+func formatValues(c config.ToolConstraint) interface{} {
+	if c.Operator == config.OperatorIn || c.Operator == config.OperatorNotIn {
+		return c.Values
+	}
+	return c.Values[0]
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newTestPolicy creates a policy with the given constraints
+func newTestPolicy(t *testing.T, constraints ...string) *Policy {
+	t.Helper()
+	p, err := NewPolicy(&config.MCPConfig{Policy: config.PolicyConfig{Constraints: constraints}})
+	if err != nil {
+		t.Fatalf("creating policy: %v", err)
+	}
+	return p
+}
+
+// TestPolicyCheck checks that constraints reject both violating values and
+// calls leaving out the constrained argument, which the handlers would
+// replace with a default, except for constraints only excluding values
+func TestPolicyCheck(t *testing.T) {
+	p := newTestPolicy(t,
+		"share_access_grant.access_level == ro",
+		"quota_update_*.project_id == 0d5e2f7c",
+		"volume_create.size <= 500",
+		"share_create.share_proto in [NFS, CEPHFS]",
+		"volume_update.name != prod",
+		"share_create.share_network_id not in [net-1, net-2]",
+	)
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{"access level given", "share_access_grant", map[string]any{"share_id": "s", "access_level": "ro"}, false},
+		{"access level violated", "share_access_grant", map[string]any{"share_id": "s", "access_level": "rw"}, true},
+		{"access level left out", "share_access_grant", map[string]any{"share_id": "s"}, true},
+		{"access level null", "share_access_grant", map[string]any{"share_id": "s", "access_level": nil}, true},
+		{"project given", "quota_update_compute", map[string]any{"project_id": "0d5e2f7c", "cores": 10.0}, false},
+		{"project violated", "quota_update_network", map[string]any{"project_id": "other", "port": 10.0}, true},
+		{"project left out", "quota_update_block_storage", map[string]any{"volumes": 10.0}, true},
+		{"project empty", "quota_update_compute", map[string]any{"project_id": "", "cores": 10.0}, true},
+		{"number within limit", "volume_create", map[string]any{"size": 500.0}, false},
+		{"number over limit", "volume_create", map[string]any{"size": 501.0}, true},
+		{"in list", "share_create", map[string]any{"share_proto": "CEPHFS"}, false},
+		{"not in list", "share_create", map[string]any{"share_proto": "CIFS"}, true},
+		{"excluded value", "volume_update", map[string]any{"volume_id": "v", "name": "prod"}, true},
+		{"other value", "volume_update", map[string]any{"volume_id": "v", "name": "dev"}, false},
+		{"excluded argument left out", "volume_update", map[string]any{"volume_id": "v", "description": "d"}, false},
+		{"excluded argument null", "volume_update", map[string]any{"volume_id": "v", "name": nil}, false},
+		{"excluded list value", "share_create", map[string]any{"share_proto": "NFS", "share_network_id": "net-2"}, true},
+		{"excluded list argument left out", "share_create", map[string]any{"share_proto": "NFS"}, false},
+		{"unconstrained tool", "volume_get", map[string]any{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(tt.tool, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check(%s, %v) = %v, want error: %t", tt.tool, tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPolicyMiddlewareMissingArgument checks that a call leaving out a
+// constrained argument never reaches the tool handler
+func TestPolicyMiddlewareMissingArgument(t *testing.T) {
+	p := newTestPolicy(t, "share_access_grant.access_level == ro")
+
+	called := false
+	handler := PolicyMiddleware(p)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("granted"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "share_access_grant"
+	request.Params.Arguments = map[string]any{"share_id": "s", "access_to": "10.0.0.0/24"}
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("calling tool: %v", err)
+	}
+	if called || !result.IsError {
+		t.Errorf("call without access_level was not rejected: handler called: %t, result: %+v", called, result)
+	}
+}
+
+// TestPolicyMiddlewareResolvedArgument checks that a constraint on an
+// argument taking a resource ID is left to ResolveMiddleware, so that a name
+// of an allowed resource is not rejected before it is resolved
+func TestPolicyMiddlewareResolvedArgument(t *testing.T) {
+	p := newTestPolicy(t, "volume_delete.volume_id in [3fa85f64-5717-4562-b3fc-2c963f66afa6]")
+
+	called := false
+	handler := PolicyMiddleware(p)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("deleted"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "volume_delete"
+	request.Params.Arguments = map[string]any{"volume_id": "data"}
+	if _, err := handler(context.Background(), request); err != nil {
+		t.Fatalf("calling tool: %v", err)
+	}
+	if !called {
+		t.Error("call by name was rejected before resolution")
+	}
+	if err := p.Check("volume_delete", map[string]any{"volume_id": "data"}); err == nil {
+		t.Error("Check accepted a name outside the allowed IDs")
+	}
+}
+
+// testTools are tool definitions that only record their calls
+func testTools(called *[]string) []ToolDefinition {
+	var tools []ToolDefinition
+	for _, def := range []struct {
+		name     string
+		readOnly bool
+	}{
+		{"volume_list", true},
+		{"volume_get", true},
+		{"volume_create", false},
+		{"volume_delete", false},
+	} {
+		name := def.name
+		tools = append(tools, ToolDefinition{
+			Name:      name,
+			ReadOnly:  def.readOnly,
+			BuildTool: func() mcp.Tool { return mcp.NewTool(name) },
+			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				*called = append(*called, name)
+				return mcp.NewToolResultText("ok"), nil
+			},
+		})
+	}
+	return tools
+}
+
+// newAccessPolicy creates a policy mapping roles to tools
+func newAccessPolicy(t *testing.T, access config.AccessConfig) *Policy {
+	t.Helper()
+	p, err := NewPolicy(&config.MCPConfig{Access: access})
+	if err != nil {
+		t.Fatalf("creating policy: %v", err)
+	}
+	return p
+}
+
+// TestPolicyPermits checks which tools the roles of a caller give access to
+func TestPolicyPermits(t *testing.T) {
+	p := newAccessPolicy(t, config.AccessConfig{
+		Roles: map[string][]string{
+			"Reader":  {config.AccessReadOnlyTools},
+			"creator": {"volume_create"},
+			"Ops":     {"volume_delete"},
+			"ops":     {"volume_create"},
+			"admin":   {"*"},
+		},
+		DefaultTools: []string{"volume_get"},
+	})
+	var called []string
+	registerToolDefinitions(server.NewMCPServer("test", "0"), "test", testTools(&called), p)
+
+	all := []string{"volume_list", "volume_get", "volume_create", "volume_delete"}
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		want     []string
+	}{
+		{"read-only tools", &auth.Identity{Roles: []string{"reader"}}, []string{"volume_list", "volume_get"}},
+		{"role case differs", &auth.Identity{Roles: []string{"READER"}}, []string{"volume_list", "volume_get"}},
+		{"multiple roles", &auth.Identity{Roles: []string{"reader", "creator"}}, []string{"volume_list", "volume_get", "volume_create"}},
+		{"roles differing in case merged", &auth.Identity{Roles: []string{"OPS"}}, []string{"volume_create", "volume_delete"}},
+		{"glob", &auth.Identity{Roles: []string{"admin"}}, all},
+		{"unmapped role", &auth.Identity{Roles: []string{"member"}}, []string{"volume_get"}},
+		{"unmapped and mapped role", &auth.Identity{Roles: []string{"member", "creator"}}, []string{"volume_create"}},
+		{"no roles", &auth.Identity{}, []string{"volume_get"}},
+		{"no identity", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tool := range all {
+				if p.Permits(tt.identity, tool) {
+					got = append(got, tool)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("permitted tools = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPolicyPermitsReadBeforeRegistration checks that @read only covers
+// tools once they are registered, as read-only is known from there
+func TestPolicyPermitsReadBeforeRegistration(t *testing.T) {
+	p := newAccessPolicy(t, config.AccessConfig{
+		Roles: map[string][]string{"reader": {config.AccessReadOnlyTools}},
+	})
+	reader := &auth.Identity{Roles: []string{"reader"}}
+	if p.Permits(reader, "volume_list") {
+		t.Error("@read permitted a tool not registered yet")
+	}
+
+	var called []string
+	registerToolDefinitions(server.NewMCPServer("test", "0"), "test", testTools(&called), p)
+	if !p.Permits(reader, "volume_list") {
+		t.Error("@read did not permit a registered read-only tool")
+	}
+	if p.Permits(reader, "volume_delete") {
+		t.Error("@read permitted a write tool")
+	}
+}
+
+// TestPolicyWithoutRoles checks that every caller may use every tool when
+// no roles are mapped, authenticated or not
+func TestPolicyWithoutRoles(t *testing.T) {
+	p := newAccessPolicy(t, config.AccessConfig{})
+	for _, identity := range []*auth.Identity{nil, {Roles: []string{"reader"}}} {
+		if !p.Permits(identity, "volume_delete") {
+			t.Errorf("Permits(%v, volume_delete) = false without role mapping", identity)
+		}
+	}
+}
+
+// TestPolicyMiddlewareFilteredTool checks that the tools hidden from a
+// caller's tools/list are also rejected when called
+func TestPolicyMiddlewareFilteredTool(t *testing.T) {
+	p := newAccessPolicy(t, config.AccessConfig{
+		Roles: map[string][]string{"reader": {config.AccessReadOnlyTools}},
+	})
+	var called []string
+	definitions := testTools(&called)
+	registerToolDefinitions(server.NewMCPServer("test", "0"), "test", definitions, p)
+
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice", Roles: []string{"reader"}})
+	var tools []mcp.Tool
+	for _, def := range definitions {
+		tools = append(tools, def.BuildTool())
+	}
+	listed := map[string]bool{}
+	for _, tool := range ToolFilter(p)(ctx, tools) {
+		listed[tool.Name] = true
+	}
+	if !listed["volume_list"] || listed["volume_delete"] {
+		t.Fatalf("tools/list = %v, want volume_list without volume_delete", listed)
+	}
+
+	for _, def := range definitions {
+		called = nil
+		request := mcp.CallToolRequest{}
+		request.Params.Name = def.Name
+		result, err := PolicyMiddleware(p)(def.Handler)(ctx, request)
+		if err != nil {
+			t.Fatalf("calling %s: %v", def.Name, err)
+		}
+		if listed[def.Name] == result.IsError || listed[def.Name] != (len(called) == 1) {
+			t.Errorf("%s listed: %t, but call rejected: %t, handler called: %v", def.Name, listed[def.Name], result.IsError, called)
+		}
+	}
+}
+
+// TestPolicySensitiveTools checks that tools handing out credentials are
+// only registered when enabled, even by a matching allow list, and are then
+// confirmed and annotated destructive
+func TestPolicySensitiveTools(t *testing.T) {
+	sensitive := ToolDefinition{Name: "coe_cluster_kubeconfig", Sensitive: true}
+	tests := []struct {
+		name    string
+		policy  config.PolicyConfig
+		allowed bool
+	}{
+		{"default", config.PolicyConfig{}, false},
+		{"allowed by glob", config.PolicyConfig{Allow: []string{"coe_*"}}, false},
+		{"enabled", config.PolicyConfig{AllowSensitive: []string{"coe_cluster_kubeconfig"}}, true},
+		{"enabled by glob", config.PolicyConfig{AllowSensitive: []string{"coe_*"}}, true},
+		{"enabled but denied", config.PolicyConfig{AllowSensitive: []string{"*"}, Deny: []string{"coe_cluster_kubeconfig"}}, false},
+		{"enabled but not allowed", config.PolicyConfig{AllowSensitive: []string{"*"}, Allow: []string{"volume_*"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPolicy(&config.MCPConfig{Policy: tt.policy})
+			if err != nil {
+				t.Fatalf("creating policy: %v", err)
+			}
+			if allowed, reason := p.allows(sensitive); allowed != tt.allowed {
+				t.Errorf("allows() = %t (%s), want %t", allowed, reason, tt.allowed)
+			}
+			// Confirmed even when destructive tools are not
+			if !p.requiresConfirmation(sensitive) {
+				t.Error("sensitive tool does not require confirmation")
+			}
+		})
+	}
+
+	sensitive.BuildTool = func() mcp.Tool { return mcp.NewTool(sensitive.Name) }
+	if hint := buildTool(sensitive).Annotations.DestructiveHint; hint == nil || !*hint {
+		t.Error("sensitive tool not annotated destructive")
+	}
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// QuotaHandler handles quota MCP tool execution requests and delegates to OpenStack client
+type QuotaHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewQuotaHandler creates a new quota handler
+func NewQuotaHandler(clients *o7k.ClientSet) *QuotaHandler {
+	return &QuotaHandler{
+		clients: clients,
+	}
+}
+
+// ComputeQuotaUpdateArgs defines the arguments for updating Nova quotas
+type ComputeQuotaUpdateArgs struct {
+	ProjectID string `json:"project_id,omitempty"`
+	o7k.UpdateComputeQuotaOpts
+}
+
+// BlockStorageQuotaUpdateArgs defines the arguments for updating Cinder quotas
+type BlockStorageQuotaUpdateArgs struct {
+	ProjectID string `json:"project_id,omitempty"`
+	o7k.UpdateBlockStorageQuotaOpts
+}
+
+// NetworkQuotaUpdateArgs defines the arguments for updating Neutron quotas
+type NetworkQuotaUpdateArgs struct {
+	ProjectID string `json:"project_id,omitempty"`
+	o7k.UpdateNetworkQuotaOpts
+}
+
+// HandleGetQuotaUsage handles the quota_usage tool
+func (h *QuotaHandler) HandleGetQuotaUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing quota_usage tool")
+
+	projectID := request.GetString("project_id", "")
+
+	usage, err := h.clients.FromContext(ctx).GetProjectQuotaUsage(ctx, projectID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("project_id", projectID).
+			Msg("Failed to get quota usage")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get quota usage: %v", err)), nil
+	}
+
+	return newJSONResult(usage, "quota usage"), nil
+}
+
+// HandleUpdateComputeQuota handles the quota_update_compute tool
+func (h *QuotaHandler) HandleUpdateComputeQuota(ctx context.Context, request mcp.CallToolRequest, args ComputeQuotaUpdateArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing quota_update_compute tool")
+
+	if args.UpdateComputeQuotaOpts == (o7k.UpdateComputeQuotaOpts{}) {
+		return mcp.NewToolResultError("At least one quota must be specified"), nil
+	}
+
+	projectID := h.targetProject(ctx, args.ProjectID)
+	quotaSet, err := h.clients.FromContext(ctx).UpdateComputeQuota(ctx, projectID, args.UpdateComputeQuotaOpts)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("project_id", projectID).
+			Msg("Failed to update compute quota")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update compute quota: %v", err)), nil
+	}
+
+	return newJSONResult(quotaSet, "compute quota"), nil
+}
+
+// HandleUpdateBlockStorageQuota handles the quota_update_block_storage tool
+func (h *QuotaHandler) HandleUpdateBlockStorageQuota(ctx context.Context, request mcp.CallToolRequest, args BlockStorageQuotaUpdateArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing quota_update_block_storage tool")
+
+	if args.UpdateBlockStorageQuotaOpts == (o7k.UpdateBlockStorageQuotaOpts{}) {
+		return mcp.NewToolResultError("At least one quota must be specified"), nil
+	}
+
+	projectID := h.targetProject(ctx, args.ProjectID)
+	quotaSet, err := h.clients.FromContext(ctx).UpdateBlockStorageQuota(ctx, projectID, args.UpdateBlockStorageQuotaOpts)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("project_id", projectID).
+			Msg("Failed to update block storage quota")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update block storage quota: %v", err)), nil
+	}
+
+	return newJSONResult(quotaSet, "block storage quota"), nil
+}
+
+// HandleUpdateNetworkQuota handles the quota_update_network tool
+func (h *QuotaHandler) HandleUpdateNetworkQuota(ctx context.Context, request mcp.CallToolRequest, args NetworkQuotaUpdateArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing quota_update_network tool")
+
+	if args.UpdateNetworkQuotaOpts == (o7k.UpdateNetworkQuotaOpts{}) {
+		return mcp.NewToolResultError("At least one quota must be specified"), nil
+	}
+
+	projectID := h.targetProject(ctx, args.ProjectID)
+	quotaSet, err := h.clients.FromContext(ctx).UpdateNetworkQuota(ctx, projectID, args.UpdateNetworkQuotaOpts)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("project_id", projectID).
+			Msg("Failed to update network quota")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update network quota: %v", err)), nil
+	}
+
+	return newJSONResult(quotaSet, "network quota"), nil
+}
+
+// targetProject defaults an empty project ID to the current project
+func (h *QuotaHandler) targetProject(ctx context.Context, projectID string) string {
+	if projectID == "" {
+		return h.clients.FromContext(ctx).ProjectID()
+	}
+	return projectID
+}
+
+// RegisterTools registers all quota tools with the MCP server
+func (h *QuotaHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering quota tools")
+
+	registerToolDefinitions(mcpServer, "quota", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// getToolDefinitions returns all quota tool definitions
+func (h *QuotaHandler) getToolDefinitions() []ToolDefinition {
+	projectIDOption := mcp.WithString("project_id",
+		mcp.Description("Optional project ID; defaults to the current project. Other projects require admin rights"),
+	)
+
+	return []ToolDefinition{
+		{
+			Name:        "quota_usage",
+			Description: "Show quota limits and usage across compute, block storage and network",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("quota_usage",
+					mcp.WithDescription("Show Nova limits, Cinder quota usage and Neutron quota details of a project in one normalized list with limit, in_use, reserved and remaining per resource. A limit of -1 means unlimited. Services that cannot be queried are reported under 'errors'."),
+					withOutputSchema[o7k.ProjectQuotaUsage](),
+					projectIDOption,
+				)
+			},
+			Handler: h.HandleGetQuotaUsage,
+		},
+		{
+			Name:        "quota_update_compute",
+			Description: "Update the compute (Nova) quotas of a project",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("quota_update_compute",
+					mcp.WithDescription("Update the Nova quotas of a project. Only the given quotas are changed; use -1 for unlimited. Requires admin rights. Returns the resulting quota limits of the project."),
+					withOutputSchema[o7k.QuotaSet](),
+					projectIDOption,
+					mcp.WithNumber("instances", mcp.Description("Number of instances")),
+					mcp.WithNumber("cores", mcp.Description("Number of vCPUs")),
+					mcp.WithNumber("ram", mcp.Description("RAM in MB")),
+					mcp.WithNumber("key_pairs", mcp.Description("Number of key pairs per user")),
+					mcp.WithNumber("server_groups", mcp.Description("Number of server groups")),
+					mcp.WithNumber("server_group_members", mcp.Description("Number of servers per server group")),
+					mcp.WithNumber("metadata_items", mcp.Description("Number of metadata items per instance")),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleUpdateComputeQuota),
+		},
+		{
+			Name:        "quota_update_block_storage",
+			Description: "Update the block storage (Cinder) quotas of a project",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("quota_update_block_storage",
+					mcp.WithDescription("Update the Cinder quotas of a project. Only the given quotas are changed; use -1 for unlimited. Requires admin rights. Returns the resulting quota limits of the project."),
+					withOutputSchema[o7k.QuotaSet](),
+					projectIDOption,
+					mcp.WithNumber("volumes", mcp.Description("Number of volumes")),
+					mcp.WithNumber("snapshots", mcp.Description("Number of snapshots")),
+					mcp.WithNumber("gigabytes", mcp.Description("Total size of volumes and snapshots in GB")),
+					mcp.WithNumber("per_volume_gigabytes", mcp.Description("Maximum size of a single volume in GB")),
+					mcp.WithNumber("backups", mcp.Description("Number of backups")),
+					mcp.WithNumber("backup_gigabytes", mcp.Description("Total size of backups in GB")),
+					mcp.WithNumber("groups", mcp.Description("Number of volume groups")),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleUpdateBlockStorageQuota),
+		},
+		{
+			Name:        "quota_update_network",
+			Description: "Update the network (Neutron) quotas of a project",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("quota_update_network",
+					mcp.WithDescription("Update the Neutron quotas of a project. Only the given quotas are changed; use -1 for unlimited. Requires admin rights. Returns the resulting quota limits of the project."),
+					withOutputSchema[o7k.QuotaSet](),
+					projectIDOption,
+					mcp.WithNumber("network", mcp.Description("Number of networks")),
+					mcp.WithNumber("subnet", mcp.Description("Number of subnets")),
+					mcp.WithNumber("port", mcp.Description("Number of ports")),
+					mcp.WithNumber("router", mcp.Description("Number of routers")),
+					mcp.WithNumber("floatingip", mcp.Description("Number of floating IPs")),
+					mcp.WithNumber("security_group", mcp.Description("Number of security groups")),
+					mcp.WithNumber("security_group_rule", mcp.Description("Number of security group rules")),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleUpdateNetworkQuota),
+		},
+	}
+}
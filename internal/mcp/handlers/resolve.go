@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// resolvedArguments maps the tool arguments taking a resource ID to the kind
+// of resource they name. Placement consumers are named after the servers
+// they usually are. Ironic and Magnum accept node and cluster names
+// themselves, so those arguments are passed on as given.
+var resolvedArguments = map[string]o7k.ResourceKind{
+	"volume_id":           o7k.ResourceVolume,
+	"server_id":           o7k.ResourceServer,
+	"share_id":            o7k.ResourceShare,
+	"share_network_id":    o7k.ResourceShareNetwork,
+	"snapshot_id":         o7k.ResourceShareSnapshot,
+	"provider_id":         o7k.ResourcePlacementProvider,
+	"consumer_id":         o7k.ResourceServer,
+	"cluster_template_id": o7k.ResourceClusterTemplate,
+}
+
+// ResolveMiddleware lets tools taking resource IDs be called with names: it
+// replaces names with the IDs of the resources they name before the call,
+// and tells which resource each name resolved to in the result. Names
+// several resources share are rejected, listing the candidates. UUIDs are
+// passed on in canonical form. The policy constraints on these arguments
+// are checked here, against the resolved IDs, so that a rule on an ID
+// neither rejects nor lets through a name or another spelling of the ID.
+func ResolveMiddleware(clients *o7k.ClientSet, policy *Policy) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			arguments := request.GetArguments()
+			var resolved []string
+			cloned := false
+			for _, argument := range slices.Sorted(maps.Keys(arguments)) {
+				kind, ok := resolvedArguments[argument]
+				if !ok {
+					continue
+				}
+				value, _ := arguments[argument].(string)
+				if value == "" {
+					continue
+				}
+
+				resource, err := clients.FromContext(ctx).Resolve(ctx, kind, value)
+				if err != nil {
+					log.Error().
+						Err(err).
+						Str("tool", request.Params.Name).
+						Str("argument", argument).
+						Msg("Failed to resolve resource")
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve '%s': %v", argument, err)), nil
+				}
+				if resource == nil {
+					continue
+				}
+
+				// The caller's arguments are left as given; the audit log
+				// records the resolved IDs separately
+				if !cloned {
+					arguments = maps.Clone(arguments)
+					request.Params.Arguments = arguments
+					cloned = true
+				}
+				arguments[argument] = resource.ID
+				if ids, ok := ctx.Value(resolvedIDsContextKey{}).(resolvedIDs); ok {
+					ids[argument] = resource.ID
+				}
+				// IDs only differing in case or hyphens are not worth telling
+				if resource.Name != "" {
+					resolved = append(resolved, fmt.Sprintf("%s %q is %s", kind, value, resource.ID))
+				}
+			}
+
+			if err := policy.Check(request.Params.Name, arguments); err != nil {
+				log.Warn().
+					Err(err).
+					Str("tool", request.Params.Name).
+					Msg("Tool call rejected by policy")
+				return mcp.NewToolResultError(fmt.Sprintf("Rejected by policy: %v", err)), nil
+			}
+
+			result, err := next(ctx, request)
+			if result != nil && len(resolved) > 0 {
+				result.Content = append(result.Content,
+					mcp.NewTextContent("Resolved by name: "+strings.Join(resolved, "; ")))
+			}
+			return result, err
+		}
+	}
+}
+
+// resolvedIDs collects the IDs the names given in one tool call resolved
+// to, by argument, for the audit log
+type resolvedIDs map[string]string
+
+// resolvedIDsContextKey stores the resolvedIDs collector in a context
+type resolvedIDsContextKey struct{}
+
+// withResolvedIDs returns a context collecting the IDs names resolve to
+func withResolvedIDs(ctx context.Context) (context.Context, resolvedIDs) {
+	ids := resolvedIDs{}
+	return context.WithValue(ctx, resolvedIDsContextKey{}, ids), ids
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestResolveMiddlewareUUIDSpelling checks that a UUID given in upper case
+// or without hyphens is checked by the policy, and passed on, in the
+// canonical form OpenStack uses
+func TestResolveMiddlewareUUIDSpelling(t *testing.T) {
+	const protected = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	p := newTestPolicy(t, "volume_delete.volume_id not in ["+protected+"]")
+
+	var got any
+	handler := ResolveMiddleware(newUnreachableClientSet(t), p)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		got = request.GetArguments()["volume_id"]
+		return mcp.NewToolResultText("deleted"), nil
+	})
+
+	tests := []struct {
+		name         string
+		volumeID     string
+		wantRejected bool
+		wantID       string
+	}{
+		{"canonical", protected, true, ""},
+		{"upper case", "3FA85F64-5717-4562-B3FC-2C963F66AFA6", true, ""},
+		{"without hyphens", "3fa85f6457174562b3fc2c963f66afa6", true, ""},
+		{"mixed case without hyphens", "3FA85f6457174562B3FC2c963f66afa6", true, ""},
+		{"other volume", "C0FFEE00-5717-4562-B3FC-2C963F66AFA6", false, "c0ffee00-5717-4562-b3fc-2c963f66afa6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			request := mcp.CallToolRequest{}
+			request.Params.Name = "volume_delete"
+			request.Params.Arguments = map[string]any{"volume_id": tt.volumeID}
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("calling tool: %v", err)
+			}
+			if result.IsError != tt.wantRejected {
+				t.Fatalf("rejected: %t, want %t: %+v", result.IsError, tt.wantRejected, result.Content)
+			}
+			if !tt.wantRejected && got != tt.wantID {
+				t.Errorf("handler called with volume_id %v, want %s", got, tt.wantID)
+			}
+			if tt.wantRejected && got != nil {
+				t.Error("handler called for a protected volume")
+			}
+			if len(result.Content) != 1 {
+				t.Errorf("result has %d contents, want no note on the spelling of an ID", len(result.Content))
+			}
+		})
+	}
+}
+
+// TestResolveMiddlewareArguments checks that the policy constraints on each
+// argument taking a resource ID apply to its canonical form
+func TestResolveMiddlewareArguments(t *testing.T) {
+	const protected = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	tests := []struct {
+		tool     string
+		argument string
+	}{
+		{"volume_delete", "volume_id"},
+		{"server_get", "server_id"},
+		{"share_delete", "share_id"},
+		{"share_network_get", "share_network_id"},
+		{"share_snapshot_get", "snapshot_id"},
+		{"placement_provider_get", "provider_id"},
+		{"placement_allocations_get", "consumer_id"},
+		{"coe_cluster_template_get", "cluster_template_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.argument, func(t *testing.T) {
+			if _, ok := resolvedArguments[tt.argument]; !ok {
+				t.Fatalf("%s is not resolved", tt.argument)
+			}
+			p := newTestPolicy(t, tt.tool+"."+tt.argument+" not in ["+protected+"]")
+			called := false
+			handler := ResolveMiddleware(newUnreachableClientSet(t), p)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				called = true
+				return mcp.NewToolResultText("done"), nil
+			})
+
+			request := mcp.CallToolRequest{}
+			request.Params.Name = tt.tool
+			request.Params.Arguments = map[string]any{tt.argument: "3FA85F6457174562B3FC2C963F66AFA6"}
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("calling tool: %v", err)
+			}
+			if !result.IsError || called {
+				t.Errorf("%s.%s given in another spelling was not rejected", tt.tool, tt.argument)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// ResourceScheme is the URI scheme of OpenStack resources
+const ResourceScheme = "openstack://"
+
+// ResourceHandler is implemented by handlers that also expose OpenStack
+// objects as MCP resources
+type ResourceHandler interface {
+	RegisterResources(mcpServer *server.MCPServer, policy *Policy) error
+}
+
+// ResourceDefinition defines a single MCP resource, or a resource template
+// when its URI contains a variable. The variable is named after the tool
+// argument taking the same ID, so that it completes like the argument.
+type ResourceDefinition struct {
+	URI         string // e.g. "openstack://volumes" or "openstack://volumes/{volume_id}"
+	Name        string
+	Description string
+
+	// Tool is the read-only tool returning the same data; resources are only
+	// exposed with it and read by callers permitted to call it
+	Tool string
+
+	// Read returns the object of the resource; id is empty for lists
+	Read func(ctx context.Context, id string) (interface{}, error)
+}
+
+// registerResourceDefinitions adds the given resources to the MCP server,
+// skipping those whose tool the policy excludes
+func registerResourceDefinitions(mcpServer *server.MCPServer, group string, resources []ResourceDefinition, policy *Policy) {
+	registeredCount := 0
+	skippedCount := 0
+
+	for _, resourceDef := range resources {
+		if ok, reason := policy.allows(ToolDefinition{Name: resourceDef.Tool, ReadOnly: true}); !ok {
+			log.Debug().
+				Str("resource", resourceDef.URI).
+				Msgf("Skipping resource (%s)", reason)
+			skippedCount++
+			continue
+		}
+
+		handler := readResourceHandler(policy, resourceDef)
+		if strings.Contains(resourceDef.URI, "{") {
+			mcpServer.AddResourceTemplate(
+				mcp.NewResourceTemplate(resourceDef.URI, resourceDef.Name,
+					mcp.WithTemplateDescription(resourceDef.Description),
+					mcp.WithTemplateMIMEType("application/json"),
+				),
+				server.ResourceTemplateHandlerFunc(handler),
+			)
+		} else {
+			mcpServer.AddResource(
+				mcp.NewResource(resourceDef.URI, resourceDef.Name,
+					mcp.WithResourceDescription(resourceDef.Description),
+					mcp.WithMIMEType("application/json"),
+				),
+				handler,
+			)
+		}
+
+		log.Debug().
+			Str("resource", resourceDef.URI).
+			Msg("Resource registered")
+		registeredCount++
+	}
+
+	log.Info().
+		Str("group", group).
+		Int("registered", registeredCount).
+		Int("skipped", skippedCount).
+		Msg("Resources registration complete")
+}
+
+// readResourceHandler checks the caller may call the tool of a resource,
+// then reads it as JSON
+func readResourceHandler(policy *Policy, resourceDef ResourceDefinition) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri := request.Params.URI
+		if !policy.Permits(auth.FromContext(ctx), resourceDef.Tool) {
+			log.Warn().Str("resource", uri).Msg("Resource read not permitted for caller")
+			return nil, fmt.Errorf("you are not permitted to read %s", uri)
+		}
+
+		id := resourceID(request)
+		log.Debug().
+			Str("resource", uri).
+			Str("id", id).
+			Msg("Reading resource")
+
+		object, err := resourceDef.Read(ctx, id)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("resource", uri).
+				Msg("Failed to read resource")
+			return nil, fmt.Errorf("reading %s: %w", uri, err)
+		}
+
+		data, err := json.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling %s: %w", uri, err)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		}, nil
+	}
+}
+
+// resourceID returns the variable matched in a resource template URI;
+// templates have a single variable, whatever its name
+func resourceID(request mcp.ReadResourceRequest) string {
+	for _, value := range request.Params.Arguments {
+		switch id := value.(type) {
+		case []string:
+			if len(id) > 0 {
+				return id[0]
+			}
+		case string:
+			return id
+		}
+	}
+	return ""
+}
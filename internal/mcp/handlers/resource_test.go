@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestReadResourceHandler checks that a resource is only read by callers
+// permitted to call its tool, with the ID matched in its URI
+func TestReadResourceHandler(t *testing.T) {
+	policy := newAccessPolicy(t, config.AccessConfig{Roles: map[string][]string{
+		"reader":  {"volume_get"},
+		"creator": {"volume_create"},
+	}})
+	var readID string
+	handler := readResourceHandler(policy, ResourceDefinition{
+		URI:  "openstack://volumes/{volume_id}",
+		Tool: "volume_get",
+		Read: func(ctx context.Context, id string) (interface{}, error) {
+			readID = id
+			return map[string]string{"id": id}, nil
+		},
+	})
+
+	tests := []struct {
+		name      string
+		identity  *auth.Identity
+		wantError bool
+	}{
+		{"permitted", &auth.Identity{Subject: "alice", Roles: []string{"reader"}}, false},
+		{"role not permitted", &auth.Identity{Subject: "bob", Roles: []string{"creator"}}, true},
+		{"no identity", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readID = ""
+			request := mcp.ReadResourceRequest{}
+			request.Params.URI = "openstack://volumes/vol-1"
+			request.Params.Arguments = map[string]any{"volume_id": []string{"vol-1"}}
+
+			ctx := context.Background()
+			if tt.identity != nil {
+				ctx = auth.NewContext(ctx, tt.identity)
+			}
+			contents, err := handler(ctx, request)
+			if tt.wantError {
+				if err == nil || readID != "" {
+					t.Errorf("read by a caller not permitted to call volume_get: %+v", contents)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reading resource: %v", err)
+			}
+			if readID != "vol-1" {
+				t.Errorf("read ID %q, want vol-1", readID)
+			}
+			text, ok := contents[0].(mcp.TextResourceContents)
+			if len(contents) != 1 || !ok || text.URI != request.Params.URI || text.Text != `{"id":"vol-1"}` {
+				t.Errorf("contents = %+v", contents)
+			}
+		})
+	}
+}
+
+// TestResourceID checks that the template variable is taken from the
+// arguments whether the SDK matched it as a list or a string
+func TestResourceID(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		want      string
+	}{
+		{"list", map[string]any{"volume_id": []string{"vol-1"}}, "vol-1"},
+		{"string", map[string]any{"server_id": "srv-1"}, "srv-1"},
+		{"empty list", map[string]any{"volume_id": []string{}}, ""},
+		{"other type", map[string]any{"volume_id": 1}, ""},
+		{"no arguments", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.ReadResourceRequest{}
+			request.Params.Arguments = tt.arguments
+			if got := resourceID(request); got != tt.want {
+				t.Errorf("resourceID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
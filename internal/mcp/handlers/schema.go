@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// dryRunOutputSchema is the output schema of dry runs, which write tools
+// declare along with their own
+var dryRunOutputSchema = outputSchema[DryRunResult]()
+
+// withOutputSchema declares the output schema of a tool, generated from the
+// Go type of its structured content
+func withOutputSchema[T any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		tool.RawOutputSchema = outputSchema[T]()
+	}
+}
+
+// outputSchema generates the JSON schema of the structured content of type
+// T. Fields Go marshals as null when unset, such as pointers, slices and
+// maps, also accept null.
+func outputSchema[T any]() json.RawMessage {
+	var generated mcp.Tool
+	mcp.WithOutputSchema[T]()(&generated)
+
+	var schema map[string]interface{}
+	data, err := json.Marshal(generated.OutputSchema)
+	if err == nil {
+		err = json.Unmarshal(data, &schema)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate output schema")
+		return nil
+	}
+	allowNull(schema, reflect.TypeFor[T]())
+
+	data, err = json.Marshal(schema)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal output schema")
+		return nil
+	}
+	return data
+}
+
+// anyOfSchema returns an object schema matching any of the given schemas
+func anyOfSchema(schemas ...json.RawMessage) json.RawMessage {
+	data, err := json.Marshal(map[string]interface{}{
+		"type":  "object",
+		"anyOf": schemas,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal output schema")
+		return nil
+	}
+	return data
+}
+
+// allowNull walks the schema generated from type t, letting the values Go
+// may marshal as null, such as nil pointers, slices and maps, accept null
+func allowNull(schema map[string]interface{}, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []string{typ, "null"}
+		}
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		allowNullFields(schema, t, false)
+	case reflect.Slice, reflect.Array:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			allowNull(items, t.Elem())
+		}
+	case reflect.Map:
+		if values, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			allowNull(values, t.Elem())
+		}
+	}
+}
+
+// allowNullFields applies allowNull to the properties of the fields of
+// struct type t, including the fields of embedded structs. Fields Go may
+// leave out are not required: those tagged omitempty or omitzero, and those
+// of embedded struct pointers, which are absent when nil, as optional says.
+func allowNullFields(schema map[string]interface{}, t reflect.Type, optional bool) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				allowNullFields(schema, embedded, optional || field.Type.Kind() == reflect.Pointer)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			allowNull(property, field.Type)
+		}
+		if optional || hasTagOption(options, "omitempty") || hasTagOption(options, "omitzero") {
+			notRequired(schema, name)
+		}
+	}
+}
+
+// hasTagOption reports whether the options of a json struct tag include
+// option
+func hasTagOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
+
+// notRequired removes a property from the required properties of a schema
+func notRequired(schema map[string]interface{}, name string) {
+	required, _ := schema["required"].([]interface{})
+	kept := required[:0]
+	for _, property := range required {
+		if property != name {
+			kept = append(kept, property)
+		}
+	}
+	if len(kept) == 0 {
+		delete(schema, "required")
+		return
+	}
+	schema["required"] = kept
+}
@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// schemaCase is a Go type and the output schema generated from it
+type schemaCase struct {
+	typ    reflect.Type
+	schema json.RawMessage
+}
+
+// newSchemaCase generates the output schema of T
+func newSchemaCase[T any]() schemaCase {
+	return schemaCase{typ: reflect.TypeFor[T](), schema: outputSchema[T]()}
+}
+
+// outputSchemaCases are the structured content types of the tools; every
+// registered tool declaring an output schema must use one of them
+var outputSchemaCases = []schemaCase{
+	newSchemaCase[DryRunResult](),
+	newSchemaCase[listResult[o7k.CloudInfo]](),
+	newSchemaCase[listResult[o7k.Volume]](),
+	newSchemaCase[o7k.Volume](),
+	newSchemaCase[VolumeDeleteResult](),
+	newSchemaCase[listResult[o7k.Share]](),
+	newSchemaCase[o7k.Share](),
+	newSchemaCase[ShareActionResult](),
+	newSchemaCase[listResult[o7k.ShareExportLocation]](),
+	newSchemaCase[listResult[o7k.ShareAccessRule]](),
+	newSchemaCase[o7k.ShareAccessRule](),
+	newSchemaCase[ShareAccessRevokeResult](),
+	newSchemaCase[listResult[o7k.ShareSnapshot]](),
+	newSchemaCase[o7k.ShareSnapshot](),
+	newSchemaCase[ShareSnapshotDeleteResult](),
+	newSchemaCase[listResult[o7k.BaremetalNode]](),
+	newSchemaCase[o7k.BaremetalNode](),
+	newSchemaCase[listResult[o7k.BaremetalPort]](),
+	newSchemaCase[listResult[o7k.BaremetalInterfaceValidation]](),
+	newSchemaCase[BaremetalNodeActionResult](),
+	newSchemaCase[listResult[o7k.ClusterTemplate]](),
+	newSchemaCase[o7k.ClusterTemplate](),
+	newSchemaCase[listResult[o7k.Cluster]](),
+	newSchemaCase[o7k.Cluster](),
+	newSchemaCase[o7k.ClusterHealth](),
+	newSchemaCase[NodeGroupResizeResult](),
+	newSchemaCase[o7k.ClusterCA](),
+	newSchemaCase[o7k.ClusterCredentials](),
+	newSchemaCase[listResult[o7k.Server]](),
+	newSchemaCase[o7k.Server](),
+	newSchemaCase[listResult[o7k.Network]](),
+	newSchemaCase[listResult[o7k.ResourceProvider]](),
+	newSchemaCase[o7k.ResourceProviderInventory](),
+	newSchemaCase[ResourceProviderTraits](),
+	newSchemaCase[o7k.ConsumerAllocations](),
+	newSchemaCase[o7k.ProjectQuotaUsage](),
+	newSchemaCase[o7k.QuotaSet](),
+	newSchemaCase[o7k.TokenInfo](),
+}
+
+// TestOutputSchemaCasesCoverTools checks that the schema of every tool is
+// one of outputSchemaCases, so that TestOutputSchemas covers new tools
+func TestOutputSchemaCasesCoverTools(t *testing.T) {
+	policy, err := NewPolicy(&config.MCPConfig{})
+	if err != nil {
+		t.Fatalf("creating policy: %v", err)
+	}
+	mcpServer := server.NewMCPServer("test", "0")
+	for _, handler := range []Handler{
+		NewCloudHandler(nil),
+		NewVolumeHandler(nil),
+		NewShareHandler(nil),
+		NewBaremetalHandler(nil),
+		NewContainerInfraHandler(nil),
+		NewComputeHandler(nil),
+		NewNetworkHandler(nil),
+		NewPlacementHandler(nil),
+		NewQuotaHandler(nil),
+		NewAuthHandler(nil),
+	} {
+		if err := handler.RegisterTools(mcpServer, policy); err != nil {
+			t.Fatalf("registering tools: %v", err)
+		}
+	}
+
+	known := map[string]bool{}
+	for _, c := range outputSchemaCases {
+		known[string(c.schema)] = true
+	}
+	for name, tool := range mcpServer.ListTools() {
+		if tool.Tool.RawOutputSchema == nil {
+			continue
+		}
+		schema := tool.Tool.RawOutputSchema
+		var combined struct {
+			AnyOf []json.RawMessage `json:"anyOf"`
+		}
+		if err := json.Unmarshal(schema, &combined); err != nil {
+			t.Fatalf("%s: decoding output schema: %v", name, err)
+		}
+		if len(combined.AnyOf) > 0 {
+			// Write tools may also return a dry run
+			schema = combined.AnyOf[0]
+		}
+		if !known[string(schema)] {
+			t.Errorf("%s: output schema generated from a type missing from outputSchemaCases: %s", name, schema)
+		}
+	}
+}
+
+// TestOutputSchemas checks that the zero value, a value whose nested
+// pointers, slices and maps are nil, and a fully populated value of each
+// structured content type match the schema generated from the type
+func TestOutputSchemas(t *testing.T) {
+	for _, c := range outputSchemaCases {
+		t.Run(c.typ.String(), func(t *testing.T) {
+			var schema map[string]any
+			if err := json.Unmarshal(c.schema, &schema); err != nil {
+				t.Fatalf("decoding schema: %v", err)
+			}
+
+			values := map[string]reflect.Value{"zero": reflect.New(c.typ).Elem()}
+			for _, depth := range []int{1, 3} {
+				value := reflect.New(c.typ).Elem()
+				populate(value, depth)
+				values[fmt.Sprintf("depth %d", depth)] = value
+			}
+			for name, value := range values {
+				data, err := json.Marshal(value.Interface())
+				if err != nil {
+					t.Fatalf("%s: marshaling: %v", name, err)
+				}
+				var decoded any
+				if err := json.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("%s: decoding: %v", name, err)
+				}
+				for _, problem := range validateSchema(schema, decoded, "$") {
+					t.Errorf("%s value %s: %s", name, data, problem)
+				}
+			}
+		})
+	}
+}
+
+// TestAnyOfSchema checks that a combined schema accepts the values of
+// either schema, and only those
+func TestAnyOfSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(anyOfSchema(outputSchema[VolumeDeleteResult](), dryRunOutputSchema), &schema); err != nil {
+		t.Fatalf("decoding schema: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		valid bool
+	}{
+		{"result", VolumeDeleteResult{VolumeID: "v1"}, true},
+		{"dry run", DryRunResult{DryRun: true}, true},
+		{"neither", map[string]any{"unrelated": true}, false},
+		{"not an object", "deleted", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("marshaling: %v", err)
+			}
+			var decoded any
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("decoding: %v", err)
+			}
+			problems := validateSchema(schema, decoded, "$")
+			if (len(problems) == 0) != tt.valid {
+				t.Errorf("%s valid: %t, want %t: %v", data, len(problems) == 0, tt.valid, problems)
+			}
+		})
+	}
+}
+
+// TestAllowNull checks which schemas accept null, and that fields left out
+// when empty, or with the nil struct pointer they are embedded from, are not
+// required
+func TestAllowNull(t *testing.T) {
+	type embedded struct {
+		Note  string `json:"note,omitempty"`
+		Count int    `json:"count"`
+	}
+	type Details struct {
+		Size  int    `json:"size"`
+		Owner string `json:"owner,omitempty"`
+	}
+	type content struct {
+		embedded
+		*Details
+		Name     string            `json:"name"`
+		Created  time.Time         `json:"created,omitzero"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Parent   *string           `json:"parent"`
+		Optional []string          `json:"optional,omitempty"`
+		Hidden   string            `json:"-"`
+	}
+	var schema struct {
+		Properties map[string]struct {
+			Type any `json:"type"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(outputSchema[content](), &schema); err != nil {
+		t.Fatalf("decoding schema: %v", err)
+	}
+
+	nullable := map[string]bool{"tags": true, "labels": true, "parent": true, "optional": true}
+	for name, property := range schema.Properties {
+		types, _ := property.Type.([]any)
+		if got := slices.Contains(types, "null"); got != nullable[name] {
+			t.Errorf("%s accepts null: %t, want %t", name, got, nullable[name])
+		}
+	}
+	if _, ok := schema.Properties["Hidden"]; ok {
+		t.Error("field not marshaled has a property")
+	}
+	slices.Sort(schema.Required)
+	if want := []string{"count", "labels", "name", "parent", "tags"}; !slices.Equal(schema.Required, want) {
+		t.Errorf("required = %v, want %v", schema.Required, want)
+	}
+
+	// What Go marshals of the zero value validates, missing fields included
+	var decoded, generated map[string]any
+	if err := json.Unmarshal(outputSchema[content](), &generated); err != nil {
+		t.Fatalf("decoding schema: %v", err)
+	}
+	data, err := json.Marshal(content{})
+	if err != nil {
+		t.Fatalf("marshaling: %v", err)
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	for _, problem := range validateSchema(generated, decoded, "$") {
+		t.Errorf("zero value %s: %s", data, problem)
+	}
+}
+
+// populate sets every field of v. Pointers, slices and maps more than depth
+// levels down are left nil; slices and maps get one element.
+func populate(v reflect.Value, depth int) {
+	if !v.CanSet() {
+		return
+	}
+	if v.Type() == reflect.TypeFor[time.Time]() {
+		v.Set(reflect.ValueOf(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+		return
+	}
+	if v.Type() == reflect.TypeFor[json.RawMessage]() {
+		v.SetBytes([]byte(`{"value":1}`))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if depth > 0 {
+			v.Set(reflect.New(v.Type().Elem()))
+			populate(v.Elem(), depth)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			populate(v.Field(i), depth)
+		}
+	case reflect.Slice:
+		if depth > 0 {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+			populate(v.Index(0), depth-1)
+		}
+	case reflect.Map:
+		if depth > 0 {
+			key := reflect.New(v.Type().Key()).Elem()
+			populate(key, depth-1)
+			value := reflect.New(v.Type().Elem()).Elem()
+			populate(value, depth-1)
+			v.Set(reflect.MakeMap(v.Type()))
+			v.SetMapIndex(key, value)
+		}
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("value"))
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	}
+}
+
+// validateSchema returns the reasons value, decoded from JSON, does not
+// match schema. Only the keywords generated schemas use are supported;
+// others are reported so that the check is never silently partial.
+func validateSchema(schema map[string]any, value any, path string) []string {
+	var problems []string
+	for keyword := range schema {
+		switch keyword {
+		case "type", "properties", "required", "additionalProperties", "items", "anyOf", "format", "description", "$schema":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unsupported schema keyword %q", path, keyword))
+		}
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		return append(problems, fmt.Sprintf("%s: %s does not have type %v", path, jsonType(value), types))
+	}
+
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		matched := false
+		for _, option := range anyOf {
+			option, _ := option.(map[string]any)
+			if len(validateSchema(option, value, path)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			problems = append(problems, fmt.Sprintf("%s: matches none of the anyOf schemas", path))
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := value[name.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: required property %q missing", path, name))
+			}
+		}
+		for name, property := range value {
+			if propertySchema, ok := properties[name].(map[string]any); ok {
+				problems = append(problems, validateSchema(propertySchema, property, path+"."+name)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					problems = append(problems, fmt.Sprintf("%s: unexpected property %q", path, name))
+				}
+			case map[string]any:
+				problems = append(problems, validateSchema(additional, property, path+"."+name)...)
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range value {
+				problems = append(problems, validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return problems
+}
+
+// matchesType reports whether value has the schema type, or one of the types
+func matchesType(types any, value any) bool {
+	switch types := types.(type) {
+	case string:
+		return types == jsonType(value) || (types == "number" && jsonType(value) == "integer")
+	case []any:
+		for _, t := range types {
+			if matchesType(t, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonType returns the schema type of a value decoded from JSON
+func jsonType(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
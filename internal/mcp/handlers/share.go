@@ -0,0 +1,829 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// ShareHandler handles shared file system (Manila) MCP tool execution requests and delegates to OpenStack client
+type ShareHandler struct {
+	clients *o7k.ClientSet
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(clients *o7k.ClientSet) *ShareHandler {
+	return &ShareHandler{
+		clients: clients,
+	}
+}
+
+// HandleListShares handles the shares_list tool
+func (h *ShareHandler) HandleListShares(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing shares_list tool")
+
+	shares, err := h.clients.FromContext(ctx).ListShares(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list shares")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list shares: %v", err)), nil
+	}
+
+	log.Debug().
+		Int("count", len(shares)).
+		Msg("Shares listed successfully")
+
+	return newListResult(shares, "shares"), nil
+}
+
+// HandleGetShare handles the share_get tool
+func (h *ShareHandler) HandleGetShare(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_get tool")
+
+	shareID := request.GetString("share_id", "")
+	if shareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+
+	share, err := h.clients.FromContext(ctx).GetShare(ctx, shareID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", shareID).
+			Msg("Failed to get share")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get share: %v", err)), nil
+	}
+
+	return newJSONResult(share, "share"), nil
+}
+
+// ShareCreateArgs defines the arguments for creating a share
+type ShareCreateArgs struct {
+	Name             string `json:"name"`
+	Size             int    `json:"size"`
+	ShareProto       string `json:"share_proto"`
+	Description      string `json:"description,omitempty"`
+	ShareType        string `json:"share_type,omitempty"`
+	ShareNetworkID   string `json:"share_network_id,omitempty"`
+	SnapshotID       string `json:"snapshot_id,omitempty"`
+	AvailabilityZone string `json:"availability_zone,omitempty"`
+}
+
+// HandleCreateShare handles the share_create tool
+func (h *ShareHandler) HandleCreateShare(ctx context.Context, request mcp.CallToolRequest, args ShareCreateArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_create tool")
+
+	if args.Name == "" {
+		return mcp.NewToolResultError("Missing or invalid 'name' parameter"), nil
+	}
+	if args.Size <= 0 {
+		return mcp.NewToolResultError("Size must be a positive number"), nil
+	}
+	if args.ShareProto == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_proto' parameter"), nil
+	}
+
+	opts := o7k.CreateShareOpts{
+		Name:             args.Name,
+		Size:             args.Size,
+		ShareProto:       args.ShareProto,
+		Description:      args.Description,
+		ShareType:        args.ShareType,
+		ShareNetworkID:   args.ShareNetworkID,
+		SnapshotID:       args.SnapshotID,
+		AvailabilityZone: args.AvailabilityZone,
+	}
+
+	share, err := h.clients.FromContext(ctx).CreateShare(ctx, opts)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("name", args.Name).
+			Msg("Failed to create share")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create share: %v", err)), nil
+	}
+
+	log.Info().
+		Str("share_id", share.ID).
+		Str("share_name", share.Name).
+		Int("size", share.Size).
+		Msg("Share created successfully")
+
+	return newJSONResult(share, "share"), nil
+}
+
+// ShareActionResult is returned by the tools deleting and resizing shares
+type ShareActionResult struct {
+	Success bool   `json:"success"`
+	ShareID string `json:"share_id"`
+	NewSize int    `json:"new_size,omitempty"`
+	Message string `json:"message"`
+}
+
+// HandleDeleteShare handles the share_delete tool
+func (h *ShareHandler) HandleDeleteShare(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_delete tool")
+
+	shareID := request.GetString("share_id", "")
+	if shareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).DeleteShare(ctx, shareID); err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", shareID).
+			Msg("Failed to delete share")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete share: %v", err)), nil
+	}
+
+	result := ShareActionResult{
+		Success: true,
+		ShareID: shareID,
+		Message: "Share deletion started",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// ShareResizeArgs defines the arguments for extending or shrinking a share
+type ShareResizeArgs struct {
+	ShareID string `json:"share_id"`
+	NewSize int    `json:"new_size"`
+}
+
+// HandleExtendShare handles the share_extend tool
+func (h *ShareHandler) HandleExtendShare(ctx context.Context, request mcp.CallToolRequest, args ShareResizeArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_extend tool")
+	return h.resizeShare(ctx, args, "extend", h.clients.FromContext(ctx).ExtendShare)
+}
+
+// HandleShrinkShare handles the share_shrink tool
+func (h *ShareHandler) HandleShrinkShare(ctx context.Context, request mcp.CallToolRequest, args ShareResizeArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_shrink tool")
+	return h.resizeShare(ctx, args, "shrink", h.clients.FromContext(ctx).ShrinkShare)
+}
+
+// resizeShare validates resize arguments and applies the given resize action
+func (h *ShareHandler) resizeShare(ctx context.Context, args ShareResizeArgs, action string, resize func(context.Context, string, int) error) (*mcp.CallToolResult, error) {
+	if args.ShareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+	if args.NewSize <= 0 {
+		return mcp.NewToolResultError("New size must be a positive number"), nil
+	}
+
+	if err := resize(ctx, args.ShareID, args.NewSize); err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", args.ShareID).
+			Int("new_size", args.NewSize).
+			Msgf("Failed to %s share", action)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to %s share: %v", action, err)), nil
+	}
+
+	result := ShareActionResult{
+		Success: true,
+		ShareID: args.ShareID,
+		NewSize: args.NewSize,
+		Message: fmt.Sprintf("Share %s started", action),
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// HandleListExportLocations handles the share_export_locations_list tool
+func (h *ShareHandler) HandleListExportLocations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_export_locations_list tool")
+
+	shareID := request.GetString("share_id", "")
+	if shareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+
+	locations, err := h.clients.FromContext(ctx).ListShareExportLocations(ctx, shareID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", shareID).
+			Msg("Failed to list export locations")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list export locations: %v", err)), nil
+	}
+
+	return newListResult(locations, "export locations"), nil
+}
+
+// HandleListAccessRules handles the share_access_list tool
+func (h *ShareHandler) HandleListAccessRules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_access_list tool")
+
+	shareID := request.GetString("share_id", "")
+	if shareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+
+	rules, err := h.clients.FromContext(ctx).ListShareAccessRules(ctx, shareID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", shareID).
+			Msg("Failed to list access rules")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list access rules: %v", err)), nil
+	}
+
+	return newListResult(rules, "access rules"), nil
+}
+
+// ShareAccessGrantArgs defines the arguments for granting share access
+type ShareAccessGrantArgs struct {
+	ShareID     string `json:"share_id"`
+	AccessType  string `json:"access_type"`
+	AccessTo    string `json:"access_to"`
+	AccessLevel string `json:"access_level,omitempty"`
+}
+
+// HandleGrantAccess handles the share_access_grant tool
+func (h *ShareHandler) HandleGrantAccess(ctx context.Context, request mcp.CallToolRequest, args ShareAccessGrantArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_access_grant tool")
+
+	if args.ShareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+	if args.AccessType == "" {
+		return mcp.NewToolResultError("Missing or invalid 'access_type' parameter"), nil
+	}
+	if args.AccessTo == "" {
+		return mcp.NewToolResultError("Missing or invalid 'access_to' parameter"), nil
+	}
+	if args.AccessLevel == "" {
+		args.AccessLevel = "rw"
+	}
+	if args.AccessLevel != "rw" && args.AccessLevel != "ro" {
+		return mcp.NewToolResultError("Access level must be either 'rw' or 'ro'"), nil
+	}
+
+	opts := o7k.GrantShareAccessOpts{
+		AccessType:  args.AccessType,
+		AccessTo:    args.AccessTo,
+		AccessLevel: args.AccessLevel,
+	}
+
+	rule, err := h.clients.FromContext(ctx).GrantShareAccess(ctx, args.ShareID, opts)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", args.ShareID).
+			Msg("Failed to grant share access")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to grant share access: %v", err)), nil
+	}
+
+	log.Info().
+		Str("share_id", args.ShareID).
+		Str("access_id", rule.ID).
+		Msg("Share access granted successfully")
+
+	return newJSONResult(rule, "access rule"), nil
+}
+
+// ShareAccessRevokeResult is returned by the share_access_revoke tool
+type ShareAccessRevokeResult struct {
+	Success  bool   `json:"success"`
+	ShareID  string `json:"share_id"`
+	AccessID string `json:"access_id"`
+	Message  string `json:"message"`
+}
+
+// ShareAccessRevokeArgs defines the arguments for revoking share access
+type ShareAccessRevokeArgs struct {
+	ShareID  string `json:"share_id"`
+	AccessID string `json:"access_id"`
+}
+
+// HandleRevokeAccess handles the share_access_revoke tool
+func (h *ShareHandler) HandleRevokeAccess(ctx context.Context, request mcp.CallToolRequest, args ShareAccessRevokeArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_access_revoke tool")
+
+	if args.ShareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+	if args.AccessID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'access_id' parameter"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).RevokeShareAccess(ctx, args.ShareID, args.AccessID); err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", args.ShareID).
+			Str("access_id", args.AccessID).
+			Msg("Failed to revoke share access")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to revoke share access: %v", err)), nil
+	}
+
+	result := ShareAccessRevokeResult{
+		Success:  true,
+		ShareID:  args.ShareID,
+		AccessID: args.AccessID,
+		Message:  "Share access revoked successfully",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// HandleListSnapshots handles the share_snapshots_list tool
+func (h *ShareHandler) HandleListSnapshots(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_snapshots_list tool")
+
+	shareID := request.GetString("share_id", "")
+
+	snapshots, err := h.clients.FromContext(ctx).ListShareSnapshots(ctx, shareID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list share snapshots")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list share snapshots: %v", err)), nil
+	}
+
+	return newListResult(snapshots, "share snapshots"), nil
+}
+
+// HandleGetSnapshot handles the share_snapshot_get tool
+func (h *ShareHandler) HandleGetSnapshot(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_snapshot_get tool")
+
+	snapshotID := request.GetString("snapshot_id", "")
+	if snapshotID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'snapshot_id' parameter"), nil
+	}
+
+	snapshot, err := h.clients.FromContext(ctx).GetShareSnapshot(ctx, snapshotID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("snapshot_id", snapshotID).
+			Msg("Failed to get share snapshot")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get share snapshot: %v", err)), nil
+	}
+
+	return newJSONResult(snapshot, "share snapshot"), nil
+}
+
+// ShareSnapshotCreateArgs defines the arguments for creating a share snapshot
+type ShareSnapshotCreateArgs struct {
+	ShareID     string `json:"share_id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// HandleCreateSnapshot handles the share_snapshot_create tool
+func (h *ShareHandler) HandleCreateSnapshot(ctx context.Context, request mcp.CallToolRequest, args ShareSnapshotCreateArgs) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_snapshot_create tool")
+
+	if args.ShareID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'share_id' parameter"), nil
+	}
+
+	opts := o7k.CreateShareSnapshotOpts{
+		ShareID:     args.ShareID,
+		Name:        args.Name,
+		Description: args.Description,
+	}
+
+	snapshot, err := h.clients.FromContext(ctx).CreateShareSnapshot(ctx, opts)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("share_id", args.ShareID).
+			Msg("Failed to create share snapshot")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create share snapshot: %v", err)), nil
+	}
+
+	return newJSONResult(snapshot, "share snapshot"), nil
+}
+
+// ShareSnapshotDeleteResult is returned by the share_snapshot_delete tool
+type ShareSnapshotDeleteResult struct {
+	Success    bool   `json:"success"`
+	SnapshotID string `json:"snapshot_id"`
+	Message    string `json:"message"`
+}
+
+// HandleDeleteSnapshot handles the share_snapshot_delete tool
+func (h *ShareHandler) HandleDeleteSnapshot(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Debug().Msg("Executing share_snapshot_delete tool")
+
+	snapshotID := request.GetString("snapshot_id", "")
+	if snapshotID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'snapshot_id' parameter"), nil
+	}
+
+	if err := h.clients.FromContext(ctx).DeleteShareSnapshot(ctx, snapshotID); err != nil {
+		log.Error().
+			Err(err).
+			Str("snapshot_id", snapshotID).
+			Msg("Failed to delete share snapshot")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete share snapshot: %v", err)), nil
+	}
+
+	result := ShareSnapshotDeleteResult{
+		Success:    true,
+		SnapshotID: snapshotID,
+		Message:    "Share snapshot deletion started",
+	}
+
+	return newJSONResult(result, "result"), nil
+}
+
+// RegisterTools registers all share-related tools with the MCP server
+func (h *ShareHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
+	log.Debug().
+		Bool("read_only", policy.ReadOnly).
+		Msg("Registering share tools")
+
+	registerToolDefinitions(mcpServer, "share", h.getToolDefinitions(), policy)
+
+	return nil
+}
+
+// RegisterResources registers the share resources with the MCP server
+func (h *ShareHandler) RegisterResources(mcpServer *server.MCPServer, policy *Policy) error {
+	registerResourceDefinitions(mcpServer, "share", []ResourceDefinition{
+		{
+			URI:         ResourceScheme + "shares",
+			Name:        "Shares",
+			Description: "All shares in the current OpenStack project",
+			Tool:        "shares_list",
+			Read: func(ctx context.Context, _ string) (interface{}, error) {
+				return h.clients.FromContext(ctx).ListShares(ctx)
+			},
+		},
+		{
+			URI:         ResourceScheme + "shares/{share_id}",
+			Name:        "Share",
+			Description: "A share by ID",
+			Tool:        "share_get",
+			Read: func(ctx context.Context, id string) (interface{}, error) {
+				return h.clients.FromContext(ctx).GetShare(ctx, id)
+			},
+		},
+	}, policy)
+
+	return nil
+}
+
+// shareTarget looks up the share of a call for confirmation
+func (h *ShareHandler) shareTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	share, err := h.clients.FromContext(ctx).GetShare(ctx, request.GetString("share_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmTarget{
+		Type:   "share",
+		ID:     share.ID,
+		Name:   share.Name,
+		Size:   fmt.Sprintf("%d GB", share.Size),
+		Status: share.Status,
+	}, nil
+}
+
+// snapshotTarget looks up the share snapshot of a call for confirmation
+func (h *ShareHandler) snapshotTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	snapshot, err := h.clients.FromContext(ctx).GetShareSnapshot(ctx, request.GetString("snapshot_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmTarget{
+		Type:   "share snapshot",
+		ID:     snapshot.ID,
+		Name:   snapshot.Name,
+		Size:   fmt.Sprintf("%d GB", snapshot.Size),
+		Status: snapshot.Status,
+	}, nil
+}
+
+// accessRuleTarget looks up the access rule of a call for confirmation
+func (h *ShareHandler) accessRuleTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	shareID := request.GetString("share_id", "")
+	accessID := request.GetString("access_id", "")
+	rules, err := h.clients.FromContext(ctx).ListShareAccessRules(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.ID == accessID {
+			return &ConfirmTarget{
+				Type:   "access rule",
+				ID:     rule.ID,
+				Name:   fmt.Sprintf("%s %s (%s)", rule.AccessType, rule.AccessTo, rule.AccessLevel),
+				Status: rule.State,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("access rule %s not found on share %s", accessID, shareID)
+}
+
+// getToolDefinitions returns all share tool definitions
+func (h *ShareHandler) getToolDefinitions() []ToolDefinition {
+	return []ToolDefinition{
+		{
+			Name:        "shares_list",
+			Description: "List all shares in the current OpenStack project",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("shares_list",
+					mcp.WithDescription("List all shared file systems (Manila shares) in the current OpenStack project. Returns share ID, name, size, protocol, status, and share type."),
+					withOutputSchema[listResult[o7k.Share]](),
+				)
+			},
+			Handler: h.HandleListShares,
+		},
+		{
+			Name:        "share_get",
+			Description: "Get details of a specific share by name or ID",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_get",
+					mcp.WithDescription("Get detailed information about a specific share by its name or ID."),
+					withOutputSchema[o7k.Share](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share to retrieve"),
+					),
+				)
+			},
+			Handler: h.HandleGetShare,
+		},
+		{
+			Name:        "share_create",
+			Description: "Create a new share in OpenStack",
+			ReadOnly:    false,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_create",
+					mcp.WithDescription("Create a new shared file system. The share will be created in the 'creating' state and transition to 'available' when ready. Grant access with share_access_grant before mounting."),
+					withOutputSchema[o7k.Share](),
+					mcp.WithString("name",
+						mcp.Required(),
+						mcp.Description("Name of the share"),
+					),
+					mcp.WithNumber("size",
+						mcp.Required(),
+						mcp.Description("Size of the share in gigabytes (GB). Must be a positive integer."),
+					),
+					mcp.WithString("share_proto",
+						mcp.Required(),
+						mcp.Description("Shared file system protocol (e.g., 'NFS', 'CIFS', 'CEPHFS')"),
+					),
+					mcp.WithString("description",
+						mcp.Description("Optional description of the share"),
+					),
+					mcp.WithString("share_type",
+						mcp.Description("Optional share type name or ID. Defaults to the configured default share type."),
+					),
+					mcp.WithString("share_network_id",
+						mcp.Description("Optional share network name or ID, required by share types with driver_handles_share_servers=True"),
+					),
+					mcp.WithString("snapshot_id",
+						mcp.Description("Optional share snapshot name or ID to create the share from"),
+					),
+					mcp.WithString("availability_zone",
+						mcp.Description("Optional availability zone for the share"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleCreateShare),
+		},
+		{
+			Name:        "share_delete",
+			Description: "Delete a share from OpenStack",
+			ReadOnly:    false,
+			Destructive: true,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_delete",
+					mcp.WithDescription("Delete a share from OpenStack. Shares that still have snapshots cannot be deleted. This operation cannot be undone."),
+					withOutputSchema[ShareActionResult](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share to delete"),
+					),
+				)
+			},
+			Handler: h.HandleDeleteShare,
+			Target:  h.shareTarget,
+		},
+		{
+			Name:        "share_extend",
+			Description: "Increase the size of a share",
+			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_extend",
+					mcp.WithDescription("Increase the size of a share. The new size must be larger than the current size."),
+					withOutputSchema[ShareActionResult](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share to extend"),
+					),
+					mcp.WithNumber("new_size",
+						mcp.Required(),
+						mcp.Description("New size of the share in gigabytes (GB)"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleExtendShare),
+		},
+		{
+			Name:        "share_shrink",
+			Description: "Reduce the size of a share",
+			ReadOnly:    false,
+			Destructive: true,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_shrink",
+					mcp.WithDescription("Reduce the size of a share. The new size must be smaller than the current size and larger than the data stored on the share."),
+					withOutputSchema[ShareActionResult](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share to shrink"),
+					),
+					mcp.WithNumber("new_size",
+						mcp.Required(),
+						mcp.Description("New size of the share in gigabytes (GB)"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleShrinkShare),
+			Target:  h.shareTarget,
+		},
+		{
+			Name:        "share_export_locations_list",
+			Description: "List the export locations of a share",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_export_locations_list",
+					mcp.WithDescription("List the export locations (mount paths) of a share. Use the preferred, non admin-only path for mounting."),
+					withOutputSchema[listResult[o7k.ShareExportLocation]](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share"),
+					),
+				)
+			},
+			Handler: h.HandleListExportLocations,
+		},
+		{
+			Name:        "share_access_list",
+			Description: "List the access rules of a share",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_access_list",
+					mcp.WithDescription("List the access rules of a share, including access type, target, level and state."),
+					withOutputSchema[listResult[o7k.ShareAccessRule]](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share"),
+					),
+				)
+			},
+			Handler: h.HandleListAccessRules,
+		},
+		{
+			Name:        "share_access_grant",
+			Description: "Grant access to a share",
+			ReadOnly:    false,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_access_grant",
+					mcp.WithDescription("Grant a client access to a share, e.g. allow an IP range to mount an NFS share."),
+					withOutputSchema[o7k.ShareAccessRule](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share"),
+					),
+					mcp.WithString("access_type",
+						mcp.Required(),
+						mcp.Description("Access rule type"),
+						mcp.Enum("ip", "cert", "user", "cephx"),
+					),
+					mcp.WithString("access_to",
+						mcp.Required(),
+						mcp.Description("Access target: an IP address or CIDR, a certificate common name, or a user name depending on access_type"),
+					),
+					mcp.WithString("access_level",
+						mcp.Description("Access level, 'rw' (default) or 'ro'"),
+						mcp.Enum("rw", "ro"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleGrantAccess),
+		},
+		{
+			Name:        "share_access_revoke",
+			Description: "Revoke an access rule from a share",
+			ReadOnly:    false,
+			Destructive: true,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_access_revoke",
+					mcp.WithDescription("Revoke an access rule from a share. Clients using the rule lose access to the share."),
+					withOutputSchema[ShareAccessRevokeResult](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share"),
+					),
+					mcp.WithString("access_id",
+						mcp.Required(),
+						mcp.Description("The UUID of the access rule to revoke"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleRevokeAccess),
+			Target:  h.accessRuleTarget,
+		},
+		{
+			Name:        "share_snapshots_list",
+			Description: "List share snapshots",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_snapshots_list",
+					mcp.WithDescription("List share snapshots in the current OpenStack project, optionally filtered by share."),
+					withOutputSchema[listResult[o7k.ShareSnapshot]](),
+					mcp.WithString("share_id",
+						mcp.Description("Optional name or UUID of the share to list snapshots for"),
+					),
+				)
+			},
+			Handler: h.HandleListSnapshots,
+		},
+		{
+			Name:        "share_snapshot_get",
+			Description: "Get details of a specific share snapshot by name or ID",
+			ReadOnly:    true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_snapshot_get",
+					mcp.WithDescription("Get detailed information about a specific share snapshot by its name or ID."),
+					withOutputSchema[o7k.ShareSnapshot](),
+					mcp.WithString("snapshot_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share snapshot to retrieve"),
+					),
+				)
+			},
+			Handler: h.HandleGetSnapshot,
+		},
+		{
+			Name:        "share_snapshot_create",
+			Description: "Create a snapshot of a share",
+			ReadOnly:    false,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_snapshot_create",
+					mcp.WithDescription("Create a point-in-time snapshot of a share."),
+					withOutputSchema[o7k.ShareSnapshot](),
+					mcp.WithString("share_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share to snapshot"),
+					),
+					mcp.WithString("name",
+						mcp.Description("Optional name of the snapshot"),
+					),
+					mcp.WithString("description",
+						mcp.Description("Optional description of the snapshot"),
+					),
+				)
+			},
+			Handler: mcp.NewTypedToolHandler(h.HandleCreateSnapshot),
+		},
+		{
+			Name:        "share_snapshot_delete",
+			Description: "Delete a share snapshot",
+			ReadOnly:    false,
+			Destructive: true,
+			Idempotent:  true,
+			OpenWorld:   true,
+			BuildTool: func() mcp.Tool {
+				return mcp.NewTool("share_snapshot_delete",
+					mcp.WithDescription("Delete a share snapshot. This operation cannot be undone."),
+					withOutputSchema[ShareSnapshotDeleteResult](),
+					mcp.WithString("snapshot_id",
+						mcp.Required(),
+						mcp.Description("The name or UUID of the share snapshot to delete"),
+					),
+				)
+			},
+			Handler: h.HandleDeleteSnapshot,
+			Target:  h.snapshotTarget,
+		},
+	}
+}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/jneo8/openstack-mcp-server/internal/o7k"
@@ -13,13 +12,13 @@ import (
 
 // VolumeHandler handles volume-related MCP tool execution requests and delegates to OpenStack client
 type VolumeHandler struct {
-	osClient *o7k.Client
+	clients *o7k.ClientSet
 }
 
 // NewVolumeHandler creates a new volume handler
-func NewVolumeHandler(osClient *o7k.Client) *VolumeHandler {
+func NewVolumeHandler(clients *o7k.ClientSet) *VolumeHandler {
 	return &VolumeHandler{
-		osClient: osClient,
+		clients: clients,
 	}
 }
 
@@ -27,24 +26,17 @@ func NewVolumeHandler(osClient *o7k.Client) *VolumeHandler {
 func (h *VolumeHandler) HandleListVolumes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	log.Debug().Msg("Executing volumes_list tool")
 
-	volumes, err := h.osClient.ListVolumes(ctx)
+	volumes, err := h.clients.FromContext(ctx).ListVolumes(ctx)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list volumes")
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to list volumes: %v", err)), nil
 	}
 
-	// Convert to JSON
-	data, err := json.Marshal(volumes)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal volumes")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal volumes: %v", err)), nil
-	}
-
 	log.Debug().
 		Int("count", len(volumes)).
 		Msg("Volumes listed successfully")
 
-	return mcp.NewToolResultText(string(data)), nil
+	return newListResult(volumes, "volumes"), nil
 }
 
 // HandleGetVolume handles the volume_get tool
@@ -59,7 +51,7 @@ func (h *VolumeHandler) HandleGetVolume(ctx context.Context, request mcp.CallToo
 
 	log.Debug().Str("volume_id", volumeID).Msg("Getting volume")
 
-	volume, err := h.osClient.GetVolume(ctx, volumeID)
+	volume, err := h.clients.FromContext(ctx).GetVolume(ctx, volumeID)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -68,19 +60,12 @@ func (h *VolumeHandler) HandleGetVolume(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to get volume: %v", err)), nil
 	}
 
-	// Convert to JSON
-	data, err := json.Marshal(volume)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal volume")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal volume: %v", err)), nil
-	}
-
 	log.Debug().
 		Str("volume_id", volumeID).
 		Str("volume_name", volume.Name).
 		Msg("Volume retrieved successfully")
 
-	return mcp.NewToolResultText(string(data)), nil
+	return newJSONResult(volume, "volume"), nil
 }
 
 // VolumeCreateArgs defines the arguments for creating a volume
@@ -118,7 +103,7 @@ func (h *VolumeHandler) HandleCreateVolume(ctx context.Context, request mcp.Call
 		VolumeType:  args.VolumeType,
 	}
 
-	volume, err := h.osClient.CreateVolume(ctx, opts)
+	volume, err := h.clients.FromContext(ctx).CreateVolume(ctx, opts)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -127,20 +112,13 @@ func (h *VolumeHandler) HandleCreateVolume(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to create volume: %v", err)), nil
 	}
 
-	// Convert to JSON
-	data, err := json.Marshal(volume)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal volume")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal volume: %v", err)), nil
-	}
-
 	log.Info().
 		Str("volume_id", volume.ID).
 		Str("volume_name", volume.Name).
 		Int("size", volume.Size).
 		Msg("Volume created successfully")
 
-	return mcp.NewToolResultText(string(data)), nil
+	return newJSONResult(volume, "volume"), nil
 }
 
 // VolumeUpdateArgs defines the arguments for updating a volume
@@ -173,7 +151,7 @@ func (h *VolumeHandler) HandleUpdateVolume(ctx context.Context, request mcp.Call
 		Description: args.Description,
 	}
 
-	volume, err := h.osClient.UpdateVolume(ctx, args.VolumeID, opts)
+	volume, err := h.clients.FromContext(ctx).UpdateVolume(ctx, args.VolumeID, opts)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -182,19 +160,19 @@ func (h *VolumeHandler) HandleUpdateVolume(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to update volume: %v", err)), nil
 	}
 
-	// Convert to JSON
-	data, err := json.Marshal(volume)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal volume")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal volume: %v", err)), nil
-	}
-
 	log.Info().
 		Str("volume_id", args.VolumeID).
 		Str("volume_name", volume.Name).
 		Msg("Volume updated successfully")
 
-	return mcp.NewToolResultText(string(data)), nil
+	return newJSONResult(volume, "volume"), nil
+}
+
+// VolumeDeleteResult is returned by the volume_delete tool
+type VolumeDeleteResult struct {
+	Success  bool   `json:"success"`
+	VolumeID string `json:"volume_id"`
+	Message  string `json:"message"`
 }
 
 // HandleDeleteVolume handles the volume_delete tool
@@ -209,7 +187,7 @@ func (h *VolumeHandler) HandleDeleteVolume(ctx context.Context, request mcp.Call
 
 	log.Debug().Str("volume_id", volumeID).Msg("Deleting volume")
 
-	err := h.osClient.DeleteVolume(ctx, volumeID)
+	err := h.clients.FromContext(ctx).DeleteVolume(ctx, volumeID)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -222,61 +200,65 @@ func (h *VolumeHandler) HandleDeleteVolume(ctx context.Context, request mcp.Call
 		Str("volume_id", volumeID).
 		Msg("Volume deleted successfully")
 
-	result := map[string]interface{}{
-		"success":   true,
-		"volume_id": volumeID,
-		"message":   "Volume deleted successfully",
-	}
-
-	data, err := json.Marshal(result)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal result")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal result: %v", err)), nil
-	}
-
-	return mcp.NewToolResultText(string(data)), nil
+	return newJSONResult(VolumeDeleteResult{
+		Success:  true,
+		VolumeID: volumeID,
+		Message:  "Volume deleted successfully",
+	}, "result"), nil
 }
 
 // RegisterTools registers all volume-related tools with the MCP server
-func (h *VolumeHandler) RegisterTools(mcpServer *server.MCPServer, readOnly bool) error {
+func (h *VolumeHandler) RegisterTools(mcpServer *server.MCPServer, policy *Policy) error {
 	log.Debug().
-		Bool("read_only", readOnly).
+		Bool("read_only", policy.ReadOnly).
 		Msg("Registering volume tools")
 
-	tools := h.getToolDefinitions()
-
-	registeredCount := 0
-	skippedCount := 0
-
-	for _, toolDef := range tools {
-		// Skip write tools if in read-only mode
-		if readOnly && !toolDef.ReadOnly {
-			log.Debug().
-				Str("tool", toolDef.Name).
-				Msg("Skipping tool (read-only mode enabled)")
-			skippedCount++
-			continue
-		}
-
-		// Build and register the tool
-		tool := toolDef.BuildTool()
-		mcpServer.AddTool(tool, toolDef.Handler)
-
-		log.Debug().
-			Str("tool", toolDef.Name).
-			Bool("read_only", toolDef.ReadOnly).
-			Msg("Tool registered")
-		registeredCount++
-	}
+	registerToolDefinitions(mcpServer, "volume", h.getToolDefinitions(), policy)
 
-	log.Info().
-		Int("registered", registeredCount).
-		Int("skipped", skippedCount).
-		Msg("Volume tools registration complete")
+	return nil
+}
+
+// RegisterResources registers the volume resources with the MCP server
+func (h *VolumeHandler) RegisterResources(mcpServer *server.MCPServer, policy *Policy) error {
+	registerResourceDefinitions(mcpServer, "volume", []ResourceDefinition{
+		{
+			URI:         ResourceScheme + "volumes",
+			Name:        "Volumes",
+			Description: "All volumes in the current OpenStack project",
+			Tool:        "volumes_list",
+			Read: func(ctx context.Context, _ string) (interface{}, error) {
+				return h.clients.FromContext(ctx).ListVolumes(ctx)
+			},
+		},
+		{
+			URI:         ResourceScheme + "volumes/{volume_id}",
+			Name:        "Volume",
+			Description: "A volume by ID",
+			Tool:        "volume_get",
+			Read: func(ctx context.Context, id string) (interface{}, error) {
+				return h.clients.FromContext(ctx).GetVolume(ctx, id)
+			},
+		},
+	}, policy)
 
 	return nil
 }
 
+// volumeTarget looks up the volume of a call for confirmation
+func (h *VolumeHandler) volumeTarget(ctx context.Context, request mcp.CallToolRequest) (*ConfirmTarget, error) {
+	volume, err := h.clients.FromContext(ctx).GetVolume(ctx, request.GetString("volume_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmTarget{
+		Type:   "volume",
+		ID:     volume.ID,
+		Name:   volume.Name,
+		Size:   fmt.Sprintf("%d GB", volume.Size),
+		Status: volume.Status,
+	}, nil
+}
+
 // getToolDefinitions returns all volume tool definitions
 func (h *VolumeHandler) getToolDefinitions() []ToolDefinition {
 	return []ToolDefinition{
@@ -284,23 +266,27 @@ func (h *VolumeHandler) getToolDefinitions() []ToolDefinition {
 			Name:        "volumes_list",
 			Description: "List all volumes in the current OpenStack project",
 			ReadOnly:    true,
+			OpenWorld:   true,
 			BuildTool: func() mcp.Tool {
 				return mcp.NewTool("volumes_list",
-					mcp.WithDescription("List all volumes in the current OpenStack project. Returns an array of volume objects with details like ID, name, size, status, and creation time."),
+					mcp.WithDescription("List all volumes in the current OpenStack project. Returns the volumes, under items, with details like ID, name, size, status, and creation time."),
+					withOutputSchema[listResult[o7k.Volume]](),
 				)
 			},
 			Handler: h.HandleListVolumes,
 		},
 		{
 			Name:        "volume_get",
-			Description: "Get details of a specific volume by ID",
+			Description: "Get details of a specific volume by name or ID",
 			ReadOnly:    true,
+			OpenWorld:   true,
 			BuildTool: func() mcp.Tool {
 				return mcp.NewTool("volume_get",
-					mcp.WithDescription("Get detailed information about a specific volume by its ID. Returns volume metadata including name, size, status, type, and timestamps."),
+					mcp.WithDescription("Get detailed information about a specific volume by its name or ID. Returns volume metadata including name, size, status, type, and timestamps."),
+					withOutputSchema[o7k.Volume](),
 					mcp.WithString("volume_id",
 						mcp.Required(),
-						mcp.Description("The UUID of the volume to retrieve"),
+						mcp.Description("The name or UUID of the volume to retrieve"),
 					),
 				)
 			},
@@ -310,9 +296,11 @@ func (h *VolumeHandler) getToolDefinitions() []ToolDefinition {
 			Name:        "volume_create",
 			Description: "Create a new volume in OpenStack",
 			ReadOnly:    false,
+			OpenWorld:   true,
 			BuildTool: func() mcp.Tool {
 				return mcp.NewTool("volume_create",
 					mcp.WithDescription("Create a new block storage volume in OpenStack. The volume will be created in the 'creating' state and transition to 'available' when ready."),
+					withOutputSchema[o7k.Volume](),
 					mcp.WithString("name",
 						mcp.Required(),
 						mcp.Description("Name of the volume"),
@@ -335,12 +323,15 @@ func (h *VolumeHandler) getToolDefinitions() []ToolDefinition {
 			Name:        "volume_update",
 			Description: "Update a volume's metadata",
 			ReadOnly:    false,
+			Idempotent:  true,
+			OpenWorld:   true,
 			BuildTool: func() mcp.Tool {
 				return mcp.NewTool("volume_update",
 					mcp.WithDescription("Update a volume's metadata such as name and description. Note: Cannot change volume size or type after creation."),
+					withOutputSchema[o7k.Volume](),
 					mcp.WithString("volume_id",
 						mcp.Required(),
-						mcp.Description("The UUID of the volume to update"),
+						mcp.Description("The name or UUID of the volume to update"),
 					),
 					mcp.WithString("name",
 						mcp.Description("New name for the volume (optional)"),
@@ -356,16 +347,21 @@ func (h *VolumeHandler) getToolDefinitions() []ToolDefinition {
 			Name:        "volume_delete",
 			Description: "Delete a volume from OpenStack",
 			ReadOnly:    false,
+			Destructive: true,
+			Idempotent:  true,
+			OpenWorld:   true,
 			BuildTool: func() mcp.Tool {
 				return mcp.NewTool("volume_delete",
 					mcp.WithDescription("Delete a volume from OpenStack. The volume must be in 'available' or 'error' state and not attached to any instance. This operation cannot be undone."),
+					withOutputSchema[VolumeDeleteResult](),
 					mcp.WithString("volume_id",
 						mcp.Required(),
-						mcp.Description("The UUID of the volume to delete"),
+						mcp.Description("The name or UUID of the volume to delete"),
 					),
 				)
 			},
 			Handler: h.HandleDeleteVolume,
+			Target:  h.volumeTarget,
 		},
 	}
 }
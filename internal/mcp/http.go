@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// endpointPath is the path of the streamable HTTP MCP endpoint
+const endpointPath = "/mcp"
+
+// newHTTPServer creates the streamable HTTP transport, guarding the MCP
+// endpoint with the configured caller authentication and TLS
+func newHTTPServer(cfg *config.MCPConfig, mcpServer *server.MCPServer, clients *o7k.ClientSet, methods *methodHandler) (*server.StreamableHTTPServer, error) {
+	// Bound how long a client may take to send a request, so that slow
+	// clients cannot hold connections open. There is no write timeout:
+	// responses stream events for as long as the session lasts.
+	httpServer := &http.Server{
+		ReadHeaderTimeout: cfg.Transport.Timeout,
+		ReadTimeout:       cfg.Transport.Timeout,
+	}
+	httpOpts := []server.StreamableHTTPOption{
+		server.WithEndpointPath(endpointPath),
+		server.WithStreamableHTTPServer(httpServer),
+	}
+	if cfg.Transport.PerRequestCredentials {
+		httpOpts = append(httpOpts, server.WithHTTPContextFunc(requestCredentialsContext))
+	}
+	if cfg.Transport.TLSCertFile != "" {
+		reloader, err := newTLSReloader(&cfg.Transport)
+		if err != nil {
+			return nil, fmt.Errorf("configuring TLS: %w", err)
+		}
+		// The files passed to the SDK are only checked at startup; each
+		// handshake uses the reloader's current certificate
+		httpServer.TLSConfig = reloader.TLSConfig()
+		httpOpts = append(httpOpts, server.WithTLSCert(cfg.Transport.TLSCertFile, cfg.Transport.TLSKeyFile))
+		log.Info().
+			Str("tls_cert_file", cfg.Transport.TLSCertFile).
+			Bool("mutual_tls", cfg.Transport.TLSClientCAFile != "").
+			Msg("HTTP transport uses TLS")
+	}
+	streamable := server.NewStreamableHTTPServer(mcpServer, httpOpts...)
+
+	authenticator, err := auth.New(&cfg.Transport.Auth, clients)
+	if err != nil {
+		return nil, fmt.Errorf("configuring HTTP authentication: %w", err)
+	}
+
+	// Requests of the methods the SDK does not handle are answered before
+	// reaching it
+	endpoint := methods.httpHandler(streamable, cfg.Transport.PerRequestCredentials)
+
+	mux := http.NewServeMux()
+	if authenticator == nil {
+		log.Warn().Msg("HTTP transport accepts unauthenticated requests")
+		mux.Handle(endpointPath, endpoint)
+	} else {
+		log.Info().
+			Str("auth_type", cfg.Transport.Auth.Type).
+			Msg("HTTP transport requires authentication")
+		mux.Handle(endpointPath, auth.Middleware(authenticator, endpoint))
+	}
+
+	// OAuth clients discover the authorization server before authenticating
+	if cfg.Transport.Auth.Type == config.HTTPAuthJWT {
+		metadata := auth.ProtectedResourceMetadataHandler(&cfg.Transport.Auth)
+		mux.Handle(auth.ProtectedResourceMetadataPath, metadata)
+		mux.Handle(auth.ProtectedResourceMetadataPath+endpointPath, metadata)
+	}
+
+	httpServer.Handler = mux
+	return streamable, nil
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// This file is the one place where JSON-RPC frames are inspected on their
+// way between the client and the SDK. The SDK advertises resource
+// subscriptions but leaves their requests to the server, and cannot answer
+// or declare completions, so both transports pass every frame they read
+// through interceptFrame and every initialize response through
+// withCompletionsCapability. Any other frame reaches the SDK, or the
+// client, byte for byte as it was sent.
+//
+// Frames are JSON-RPC messages, one per stdio line or HTTP request body.
+// The SDK only decodes single messages, and MCP no longer allows batches,
+// so a batch is answered here with one error rather than partly by each.
+
+// MCP methods the SDK leaves to the server; their requests are answered by
+// the method handler before frames reach the SDK
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+	methodCompletionComplete   = "completion/complete"
+)
+
+// interceptedMethods are the quoted names of the methods answered here;
+// a frame containing none of them is passed on without being decoded
+var interceptedMethods = [][]byte{
+	[]byte(`"` + methodResourcesSubscribe + `"`),
+	[]byte(`"` + methodResourcesUnsubscribe + `"`),
+	[]byte(`"` + methodCompletionComplete + `"`),
+}
+
+// methodRequest is a request of a method answered by the method handler
+type methodRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      mcp.RequestId   `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// interceptFrame decides what becomes of a frame read from the client. It
+// returns the request when the method handler answers the frame, the
+// response when the frame is answered right away, and neither when the
+// frame is passed on to the SDK unchanged.
+func interceptFrame(frame []byte) (*methodRequest, mcp.JSONRPCMessage) {
+	if trimmed := bytes.TrimLeft(frame, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		return nil, methodError(mcp.NewRequestId(nil), mcp.INVALID_REQUEST, "JSON-RPC batches are not supported")
+	}
+
+	// Cheap check first, every frame of the session passes here
+	if !containsAny(frame, interceptedMethods) {
+		return nil, nil
+	}
+
+	// Invalid frames and notifications are left to the SDK, which reports
+	// or ignores them as it does for every other method
+	var request methodRequest
+	if err := json.Unmarshal(frame, &request); err != nil || request.ID.IsNil() {
+		return nil, nil
+	}
+	switch request.Method {
+	case methodResourcesSubscribe, methodResourcesUnsubscribe, methodCompletionComplete:
+		return &request, nil
+	}
+	return nil, nil
+}
+
+// containsAny reports whether frame contains one of the byte strings
+func containsAny(frame []byte, values [][]byte) bool {
+	for _, value := range values {
+		if bytes.Contains(frame, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInitializeRequest reports whether a frame is an initialize request,
+// whose response withCompletionsCapability expects
+func isInitializeRequest(frame []byte) bool {
+	if !bytes.Contains(frame, []byte(`"`+string(mcp.MethodInitialize)+`"`)) {
+		return false
+	}
+	var request struct {
+		Method string `json:"method"`
+	}
+	return json.Unmarshal(frame, &request) == nil && request.Method == string(mcp.MethodInitialize)
+}
+
+// withCompletionsCapability adds the completions capability, which the SDK
+// cannot declare, to an initialize response. Other frames are returned
+// unchanged.
+func withCompletionsCapability(frame []byte) []byte {
+	if !bytes.Contains(frame, []byte(`"serverInfo"`)) {
+		return frame
+	}
+
+	var response, result, capabilities map[string]json.RawMessage
+	if json.Unmarshal(frame, &response) != nil ||
+		json.Unmarshal(response["result"], &result) != nil ||
+		result["serverInfo"] == nil ||
+		json.Unmarshal(result["capabilities"], &capabilities) != nil {
+		return frame
+	}
+
+	capabilities["completions"] = json.RawMessage("{}")
+	var err error
+	if result["capabilities"], err = json.Marshal(capabilities); err != nil {
+		return frame
+	}
+	if response["result"], err = json.Marshal(result); err != nil {
+		return frame
+	}
+	patched, err := json.Marshal(response)
+	if err != nil {
+		return frame
+	}
+	if bytes.HasSuffix(frame, []byte("\n")) {
+		patched = append(patched, '\n')
+	}
+	return patched
+}
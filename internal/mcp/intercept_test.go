@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/handlers"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestInterceptFrame checks which frames are answered and which reach the
+// SDK
+func TestInterceptFrame(t *testing.T) {
+	tests := []struct {
+		name       string
+		frame      string
+		wantMethod string // Method of the request answered, if any
+		wantError  int    // Code of the error answered right away, if any
+	}{
+		{"completion", `{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{}}`, methodCompletionComplete, 0},
+		{"subscribe", `{"jsonrpc":"2.0","id":"a","method":"resources/subscribe","params":{"uri":"openstack://volumes/v1"}}`, methodResourcesSubscribe, 0},
+		{"unsubscribe", `{"jsonrpc":"2.0","id":2,"method":"resources/unsubscribe","params":{"uri":"openstack://volumes/v1"}}`, methodResourcesUnsubscribe, 0},
+		{"other method", `{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"openstack://volumes/v1"}}`, "", 0},
+		{"method named in arguments", `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"volume_create","arguments":{"name":"completion/complete","description":"resources/subscribe"}}}`, "", 0},
+		{"method name as a value", `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"arguments":{"x":"completion/complete"}}}`, "", 0},
+		{"notification", `{"jsonrpc":"2.0","method":"completion/complete"}`, "", 0},
+		{"invalid JSON", `{"jsonrpc":"2.0","id":6,"method":"completion/complete"`, "", 0},
+		{"response", `{"jsonrpc":"2.0","id":7,"result":{}}`, "", 0},
+		{"batch", `[{"jsonrpc":"2.0","id":1,"method":"completion/complete"},{"jsonrpc":"2.0","id":2,"method":"tools/list"}]`, "", mcp.INVALID_REQUEST},
+		{"batch without intercepted methods", " \n[{\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"tools/list\"}]\n", "", mcp.INVALID_REQUEST},
+		{"empty batch", `[]`, "", mcp.INVALID_REQUEST},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, response := interceptFrame([]byte(tt.frame))
+
+			var method string
+			if request != nil {
+				method = request.Method
+			}
+			if method != tt.wantMethod {
+				t.Errorf("intercepted method = %q, want %q", method, tt.wantMethod)
+			}
+
+			var code int
+			if response != nil {
+				jsonrpcError, ok := response.(mcp.JSONRPCError)
+				if !ok {
+					t.Fatalf("response = %+v, want an error", response)
+				}
+				code = jsonrpcError.Error.Code
+			}
+			if code != tt.wantError {
+				t.Errorf("error code = %d, want %d", code, tt.wantError)
+			}
+		})
+	}
+}
+
+// TestWithCompletionsCapability checks that only initialize responses are
+// changed, and that their other capabilities are kept
+func TestWithCompletionsCapability(t *testing.T) {
+	initialize := `{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18","capabilities":{"tools":{"listChanged":true}},"serverInfo":{"name":"test","version":"0"}}}` + "\n"
+	patched := withCompletionsCapability([]byte(initialize))
+
+	if !bytes.HasSuffix(patched, []byte("\n")) {
+		t.Error("trailing newline dropped")
+	}
+	var response struct {
+		Result struct {
+			Capabilities map[string]json.RawMessage `json:"capabilities"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(patched, &response); err != nil {
+		t.Fatalf("decoding patched response: %v", err)
+	}
+	for _, capability := range []string{"tools", "completions"} {
+		if _, ok := response.Result.Capabilities[capability]; !ok {
+			t.Errorf("capability %s missing from %s", capability, patched)
+		}
+	}
+
+	unchanged := []string{
+		`{"jsonrpc":"2.0","id":2,"result":{"tools":[]}}`,
+		`{"jsonrpc":"2.0","id":3,"result":{"content":[{"type":"text","text":"{\"serverInfo\":{}}"}]}}`,
+		`{"jsonrpc":"2.0","id":4,"result":{"structuredContent":{"serverInfo":"x"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/message","params":{"serverInfo":{}}}`,
+	}
+	for _, frame := range unchanged {
+		if got := withCompletionsCapability([]byte(frame)); string(got) != frame {
+			t.Errorf("withCompletionsCapability(%s) = %s, want it unchanged", frame, got)
+		}
+	}
+}
+
+// TestFilterStdin checks that frames the SDK handles are copied byte for
+// byte, and that the others are answered on stdout in order
+func TestFilterStdin(t *testing.T) {
+	policy, err := handlers.NewPolicy(&config.MCPConfig{})
+	if err != nil {
+		t.Fatalf("creating policy: %v", err)
+	}
+	methods := &methodHandler{completions: &completions{completer: handlers.NewCompleter(nil, policy)}}
+
+	forwarded := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}` + "\n",
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"volume_create","arguments":{"name":"completion/complete"}}}` + "\n",
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+	}
+	in := strings.Join([]string{
+		forwarded[0],
+		`{"jsonrpc":"2.0","id":3,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"p"},"argument":{"name":"unknown","value":""}}}` + "\n",
+		forwarded[1],
+		`[{"jsonrpc":"2.0","id":4,"method":"completion/complete"}]` + "\n",
+		`{"jsonrpc":"2.0","id":5,"method":"completion/complete","params":{"ref":{"type":"ref/tool","name":"volume_delete"},"argument":{"name":"volume_id","value":""}}}` + "\n",
+		forwarded[2],
+	}, "")
+
+	sessionIDs := make(chan string, 1)
+	sessionIDs <- "session"
+	var out, stdout bytes.Buffer
+	if err := filterStdin(context.Background(), strings.NewReader(in), &out, &stdout, methods, sessionIDs); err != nil {
+		t.Fatalf("filterStdin: %v", err)
+	}
+
+	if got, want := out.String(), strings.Join(forwarded, ""); got != want {
+		t.Errorf("forwarded frames:\n%s\nwant:\n%s", got, want)
+	}
+
+	type answer struct {
+		ID     any             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	var responses []answer
+	for _, line := range strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n") {
+		var response answer
+		if err := json.Unmarshal([]byte(line), &response); err != nil {
+			t.Fatalf("decoding response %q: %v", line, err)
+		}
+		responses = append(responses, response)
+	}
+	if len(responses) != 3 {
+		t.Fatalf("responses = %d, want 3:\n%s", len(responses), stdout.String())
+	}
+	if responses[0].ID != float64(3) || responses[0].Result == nil {
+		t.Errorf("completion response = %+v, want a result for id 3", responses[0])
+	}
+	if responses[1].ID != nil || responses[1].Error == nil || responses[1].Error.Code != mcp.INVALID_REQUEST {
+		t.Errorf("batch response = %+v, want an invalid request error without id", responses[1])
+	}
+	if responses[2].ID != float64(5) || responses[2].Error == nil || responses[2].Error.Code != mcp.INVALID_PARAMS {
+		t.Errorf("ref/tool completion response = %+v, want an invalid params error for id 5", responses[2])
+	}
+}
+
+// TestHTTPHandlerIntercept checks that posted batches are rejected without
+// reaching the SDK, and that other frames reach it unchanged
+func TestHTTPHandlerIntercept(t *testing.T) {
+	var received []string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+	})
+	handler := (&methodHandler{}).httpHandler(next, false)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		forwarded  bool
+	}{
+		{"request", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`, http.StatusOK, true},
+		{"method named in arguments", `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"arguments":{"name":"resources/subscribe"}}}`, http.StatusOK, true},
+		{"batch", `[{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"openstack://volumes/v1"}}]`, http.StatusBadRequest, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, endpointPath, strings.NewReader(tt.body)))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.forwarded && (len(received) != 1 || received[0] != tt.body) {
+				t.Errorf("SDK received %q, want the frame unchanged", received)
+			}
+			if !tt.forwarded && len(received) != 0 {
+				t.Errorf("SDK received %q, want nothing", received)
+			}
+		})
+	}
+}
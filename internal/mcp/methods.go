@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// maxRequestBodySize is the largest request body the HTTP endpoint reads;
+// MCP requests are small JSON-RPC messages
+const maxRequestBodySize = 4 << 20
+
+// methodHandler answers the requests of the MCP methods the SDK does not
+// handle
+type methodHandler struct {
+	subs        *subscriptions
+	completions *completions
+}
+
+// handle answers a request of a session
+func (h *methodHandler) handle(ctx context.Context, sessionID string, request *methodRequest) mcp.JSONRPCMessage {
+	if request.Method == methodCompletionComplete {
+		return h.completions.handle(ctx, request)
+	}
+	return h.subs.handle(ctx, sessionID, request)
+}
+
+// methodError creates the JSON-RPC error response of a request
+func methodError(id mcp.RequestId, code int, message string) mcp.JSONRPCError {
+	return mcp.JSONRPCError{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Error:   mcp.NewJSONRPCErrorDetails(code, message, nil),
+	}
+}
+
+// httpHandler intercepts the frames posted to the streamable HTTP endpoint,
+// answering those interceptFrame keeps from the SDK and passing every other
+// request to next
+func (h *methodHandler) httpHandler(next http.Handler, perRequestCredentials bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+		case http.MethodDelete:
+			// The SDK keeps terminated sessions registered, so they end
+			// here along with their subscriptions
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			if recorder.status == http.StatusOK {
+				h.subs.removeSession(r.Header.Get(server.HeaderKeySessionID))
+			}
+			return
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		request, response := interceptFrame(body)
+		status := http.StatusOK
+		switch {
+		case request != nil:
+			ctx := r.Context()
+			if perRequestCredentials {
+				ctx = requestCredentialsContext(ctx, r)
+			}
+			response = h.handle(ctx, r.Header.Get(server.HeaderKeySessionID), request)
+		case response != nil:
+			// Frames the SDK would not accept either are bad requests,
+			// as the SDK answers them
+			status = http.StatusBadRequest
+		case isInitializeRequest(body):
+			serveInitialize(next, w, r)
+			return
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error().Err(err).Msg("Failed to write response")
+		}
+	})
+}
+
+// serveInitialize serves an initialize request, adding the completions
+// capability to the response
+func serveInitialize(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	recorder := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+	next.ServeHTTP(recorder, r)
+
+	body := recorder.body.Bytes()
+	for key, values := range recorder.header {
+		w.Header()[key] = values
+	}
+	if recorder.status == http.StatusOK {
+		body = withCompletionsCapability(body)
+		w.Header().Del("Content-Length")
+	}
+	w.WriteHeader(recorder.status)
+	if _, err := w.Write(body); err != nil {
+		log.Error().Err(err).Msg("Failed to write initialize response")
+	}
+}
+
+// statusRecorder records the status code of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// bufferedResponse holds a response until it is complete
+type bufferedResponse struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wroteHeader {
+		b.status = status
+		b.wroteHeader = true
+	}
+}
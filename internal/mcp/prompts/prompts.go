@@ -0,0 +1,254 @@
+// Package prompts provides MCP prompts guiding the model through common
+// OpenStack runbooks, defined in YAML catalogs
+package prompts
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/handlers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// builtinCatalog holds the prompts shipped with the server
+//
+//go:embed catalog.yaml
+var builtinCatalog []byte
+
+// catalog is the format of prompt catalog files
+type catalog struct {
+	Prompts []*Prompt `yaml:"prompts"`
+}
+
+// Prompt is a runbook prompt: instructions rendered from a template with the
+// arguments given by the client
+type Prompt struct {
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Arguments   []Argument `yaml:"arguments"`
+
+	// Tools the runbook cannot do without; the prompt is only offered when
+	// they are all registered, and only to callers permitted to call them
+	Tools []string `yaml:"tools"`
+
+	// Go text/template with the arguments as fields, e.g. {{.server_id}}
+	Template string `yaml:"template"`
+
+	tmpl *template.Template
+}
+
+// Argument is an argument of a prompt
+type Argument struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+// Load returns the built-in prompts, followed by the prompts of the given
+// catalog files. A prompt replaces any earlier prompt of the same name.
+func Load(files []string) ([]*Prompt, error) {
+	prompts, err := parseCatalog(builtinCatalog, "built-in catalog")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading prompt catalog: %w", err)
+		}
+		extra, err := parseCatalog(data, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, prompt := range extra {
+			prompts = replaceOrAppend(prompts, prompt)
+		}
+		log.Info().
+			Str("file", file).
+			Int("prompts", len(extra)).
+			Msg("Loaded prompt catalog")
+	}
+
+	return prompts, nil
+}
+
+// parseCatalog parses and validates a prompt catalog
+func parseCatalog(data []byte, source string) ([]*Prompt, error) {
+	var c catalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing prompt catalog %s: %w", source, err)
+	}
+
+	var prompts []*Prompt
+	for i, prompt := range c.Prompts {
+		if err := prompt.compile(); err != nil {
+			return nil, fmt.Errorf("prompt catalog %s: prompt %d: %w", source, i+1, err)
+		}
+		prompts = replaceOrAppend(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// compile validates a prompt and parses its template
+func (p *Prompt) compile() error {
+	if p.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(p.Template) == "" {
+		return fmt.Errorf("%s: template is required", p.Name)
+	}
+
+	seen := map[string]bool{}
+	for _, arg := range p.Arguments {
+		if arg.Name == "" {
+			return fmt.Errorf("%s: argument name is required", p.Name)
+		}
+		if seen[arg.Name] {
+			return fmt.Errorf("%s: duplicate argument %q", p.Name, arg.Name)
+		}
+		seen[arg.Name] = true
+	}
+
+	tmpl, err := template.New(p.Name).Option("missingkey=zero").Parse(p.Template)
+	if err != nil {
+		return fmt.Errorf("%s: parsing template: %w", p.Name, err)
+	}
+	p.tmpl = tmpl
+	return nil
+}
+
+// replaceOrAppend adds a prompt to a list, replacing the one of the same name
+func replaceOrAppend(prompts []*Prompt, prompt *Prompt) []*Prompt {
+	for i, existing := range prompts {
+		if existing.Name == prompt.Name {
+			prompts[i] = prompt
+			return prompts
+		}
+	}
+	return append(prompts, prompt)
+}
+
+// Register adds the prompts to the MCP server, skipping those needing tools
+// the server does not register. Callers are only offered, and can only get,
+// the prompts whose tools their roles all permit; the hooks must be those of
+// the server.
+func Register(mcpServer *server.MCPServer, hooks *server.Hooks, prompts []*Prompt, policy *handlers.Policy) {
+	tools := mcpServer.ListTools()
+	registered := map[string]*Prompt{}
+	skippedCount := 0
+
+	for _, prompt := range prompts {
+		if missing := missingTool(prompt, tools); missing != "" {
+			log.Debug().
+				Str("prompt", prompt.Name).
+				Msgf("Skipping prompt (tool %s is not available)", missing)
+			skippedCount++
+			continue
+		}
+
+		opts := []mcp.PromptOption{mcp.WithPromptDescription(prompt.Description)}
+		for _, arg := range prompt.Arguments {
+			argOpts := []mcp.ArgumentOption{mcp.ArgumentDescription(arg.Description)}
+			if arg.Required {
+				argOpts = append(argOpts, mcp.RequiredArgument())
+			}
+			opts = append(opts, mcp.WithArgument(arg.Name, argOpts...))
+		}
+		mcpServer.AddPrompt(mcp.NewPrompt(prompt.Name, opts...), prompt.handler(policy))
+		registered[prompt.Name] = prompt
+
+		log.Debug().
+			Str("prompt", prompt.Name).
+			Msg("Prompt registered")
+	}
+
+	// The SDK has no prompt filter; the listing is filtered once built
+	hooks.AddAfterListPrompts(func(ctx context.Context, id any, request *mcp.ListPromptsRequest, result *mcp.ListPromptsResult) {
+		permitted := make([]mcp.Prompt, 0, len(result.Prompts))
+		for _, listed := range result.Prompts {
+			if prompt, ok := registered[listed.Name]; !ok || prompt.permitted(ctx, policy) {
+				permitted = append(permitted, listed)
+			}
+		}
+		result.Prompts = permitted
+	})
+
+	log.Info().
+		Int("registered", len(registered)).
+		Int("skipped", skippedCount).
+		Msg("Prompts registration complete")
+}
+
+// permitted reports whether the caller may call every tool of the prompt
+func (p *Prompt) permitted(ctx context.Context, policy *handlers.Policy) bool {
+	identity := auth.FromContext(ctx)
+	for _, tool := range p.Tools {
+		if !policy.Permits(identity, tool) {
+			return false
+		}
+	}
+	return true
+}
+
+// missingTool returns the first tool of a prompt that is not registered
+func missingTool(prompt *Prompt, tools map[string]*server.ServerTool) string {
+	for _, tool := range prompt.Tools {
+		if _, ok := tools[tool]; !ok {
+			return tool
+		}
+	}
+	return ""
+}
+
+// handler renders the prompt with the arguments of a request, for callers
+// permitted to use it
+func (p *Prompt) handler(policy *handlers.Policy) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		if !p.permitted(ctx, policy) {
+			log.Warn().
+				Str("prompt", p.Name).
+				Msg("Prompt not permitted for caller")
+			return nil, fmt.Errorf("you are not permitted to use prompt %s", p.Name)
+		}
+
+		text, err := p.Render(request.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debug().
+			Str("prompt", p.Name).
+			Msg("Rendered prompt")
+		return mcp.NewGetPromptResult(p.Description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+		}), nil
+	}
+}
+
+// Render renders the prompt's instructions; optional arguments not given
+// are empty
+func (p *Prompt) Render(args map[string]string) (string, error) {
+	data := make(map[string]string, len(p.Arguments))
+	for _, arg := range p.Arguments {
+		value := strings.TrimSpace(args[arg.Name])
+		if arg.Required && value == "" {
+			return "", fmt.Errorf("missing required argument %q", arg.Name)
+		}
+		data[arg.Name] = value
+	}
+
+	var text strings.Builder
+	if err := p.tmpl.Execute(&text, data); err != nil {
+		return "", fmt.Errorf("rendering prompt %s: %w", p.Name, err)
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,211 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/auth"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/handlers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// writeCatalog writes a prompt catalog to a temporary file
+func writeCatalog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "prompts.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing catalog: %v", err)
+	}
+	return path
+}
+
+// names returns the names of the prompts
+func names(prompts []*Prompt) []string {
+	result := make([]string, len(prompts))
+	for i, prompt := range prompts {
+		result[i] = prompt.Name
+	}
+	return result
+}
+
+// TestLoadBuiltin checks that the built-in catalog parses and that its
+// prompts name tools and arguments
+func TestLoadBuiltin(t *testing.T) {
+	prompts, err := Load(nil)
+	if err != nil {
+		t.Fatalf("loading built-in prompts: %v", err)
+	}
+	for _, name := range []string{"troubleshoot_server", "delete_orphaned_volumes", "capacity_report"} {
+		if !slices.Contains(names(prompts), name) {
+			t.Errorf("built-in prompt %s missing from %v", name, names(prompts))
+		}
+	}
+	for _, prompt := range prompts {
+		if len(prompt.Tools) == 0 {
+			t.Errorf("%s: no tools", prompt.Name)
+		}
+	}
+}
+
+// TestLoadReplacesBuiltin checks that a catalog prompt replaces the built-in
+// prompt of the same name in place, and that new prompts are appended
+func TestLoadReplacesBuiltin(t *testing.T) {
+	builtin, err := Load(nil)
+	if err != nil {
+		t.Fatalf("loading built-in prompts: %v", err)
+	}
+	file := writeCatalog(t, `prompts:
+  - name: troubleshoot_server
+    description: Site runbook
+    tools: [server_get]
+    template: Follow the site runbook.
+  - name: rotate_keys
+    tools: [auth_token_info]
+    template: Rotate the keys.
+`)
+
+	prompts, err := Load([]string{file})
+	if err != nil {
+		t.Fatalf("loading prompts: %v", err)
+	}
+	want := append(names(builtin), "rotate_keys")
+	if got := names(prompts); !slices.Equal(got, want) {
+		t.Fatalf("prompts = %v, want %v", got, want)
+	}
+	replaced := prompts[slices.Index(want, "troubleshoot_server")]
+	if replaced.Description != "Site runbook" {
+		t.Errorf("troubleshoot_server description = %q, want the catalog's", replaced.Description)
+	}
+}
+
+// TestLoadInvalid checks that invalid catalogs are rejected
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not yaml":           "prompts: [",
+		"no name":            "prompts:\n  - template: Do it.\n",
+		"no template":        "prompts:\n  - name: p\n",
+		"unnamed argument":   "prompts:\n  - name: p\n    arguments: [{description: d}]\n    template: Do it.\n",
+		"duplicate argument": "prompts:\n  - name: p\n    arguments: [{name: a}, {name: a}]\n    template: Do it.\n",
+		"bad template":       "prompts:\n  - name: p\n    template: '{{.a'\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load([]string{writeCatalog(t, content)}); err == nil {
+				t.Error("Load() succeeded, want error")
+			}
+		})
+	}
+	if _, err := Load([]string{filepath.Join(t.TempDir(), "missing.yaml")}); err == nil {
+		t.Error("Load() succeeded with a missing file")
+	}
+}
+
+// TestRender checks required arguments and that optional or undeclared
+// arguments render empty
+func TestRender(t *testing.T) {
+	prompts, err := parseCatalog([]byte(`prompts:
+  - name: p
+    arguments:
+      - name: server_id
+        required: true
+      - name: days
+    template: "server={{.server_id}} days={{.days}} other={{.other}}{{if .days}} old{{end}}"
+`), "test")
+	if err != nil {
+		t.Fatalf("parsing catalog: %v", err)
+	}
+	prompt := prompts[0]
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"all arguments", map[string]string{"server_id": "web-1", "days": "30"}, "server=web-1 days=30 other= old", false},
+		{"optional left out", map[string]string{"server_id": "web-1"}, "server=web-1 days= other=", false},
+		{"values trimmed", map[string]string{"server_id": " web-1 ", "days": " "}, "server=web-1 days= other=", false},
+		{"undeclared argument ignored", map[string]string{"server_id": "web-1", "other": "x"}, "server=web-1 days= other=", false},
+		{"required left out", map[string]string{"days": "30"}, "", true},
+		{"required blank", map[string]string{"server_id": "  "}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prompt.Render(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render(%v) error = %v, want error: %t", tt.args, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Render(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRegisterPerCaller checks that callers are only offered, and can only
+// get, the prompts whose tools their roles permit
+func TestRegisterPerCaller(t *testing.T) {
+	policy, err := handlers.NewPolicy(&config.MCPConfig{Access: config.AccessConfig{
+		Roles: map[string][]string{
+			"reader": {"*_list", "*_get"},
+			"admin":  {"*"},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("creating policy: %v", err)
+	}
+	hooks := &server.Hooks{}
+	mcpServer := server.NewMCPServer("test", "0", server.WithPromptCapabilities(false), server.WithHooks(hooks))
+	for _, tool := range []string{"volumes_list", "servers_list", "volume_get", "volume_delete", "server_get"} {
+		mcpServer.AddTool(mcp.NewTool(tool), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	}
+	prompts, err := Load(nil)
+	if err != nil {
+		t.Fatalf("loading prompts: %v", err)
+	}
+	Register(mcpServer, hooks, prompts, policy)
+
+	tests := []struct {
+		role string
+		want []string
+	}{
+		// capacity_report needs quota_usage, which is not registered
+		{"reader", []string{"troubleshoot_server"}},
+		{"admin", []string{"delete_orphaned_volumes", "troubleshoot_server"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice", Roles: []string{tt.role}})
+			response := mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`))
+			result, ok := response.(mcp.JSONRPCResponse)
+			if !ok {
+				t.Fatalf("prompts/list response = %+v", response)
+			}
+			var got []string
+			for _, prompt := range result.Result.(mcp.ListPromptsResult).Prompts {
+				got = append(got, prompt.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("prompts/list = %v, want %v", got, tt.want)
+			}
+
+			response = mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"prompts/get","params":{"name":"delete_orphaned_volumes"}}`))
+			_, rejected := response.(mcp.JSONRPCError)
+			if permitted := slices.Contains(tt.want, "delete_orphaned_volumes"); rejected == permitted {
+				t.Errorf("prompts/get delete_orphaned_volumes rejected: %t, want %t: %+v", rejected, !permitted, response)
+			}
+			if rejected && !strings.Contains(response.(mcp.JSONRPCError).Error.Message, "not permitted") {
+				t.Errorf("prompts/get error = %q, want not permitted", response.(mcp.JSONRPCError).Error.Message)
+			}
+		})
+	}
+}
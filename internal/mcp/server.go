@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/audit"
 	"github.com/jneo8/openstack-mcp-server/internal/mcp/handlers"
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/prompts"
 	"github.com/jneo8/openstack-mcp-server/internal/o7k"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/rs/zerolog/log"
@@ -14,53 +16,131 @@ import (
 // Server represents the MCP server with all its dependencies
 type Server struct {
 	config     *config.MCPConfig
-	osClient   *o7k.Client
+	clients    *o7k.ClientSet
 	mcpServer  *server.MCPServer
 	handlers   []handlers.Handler
 	httpServer *server.StreamableHTTPServer
+	audit      *audit.Logger
+	methods    *methodHandler
 }
 
 // NewServer creates a new MCP server instance
-func NewServer(cfg *config.MCPConfig, osClient *o7k.Client) (*Server, error) {
+func NewServer(cfg *config.MCPConfig, clients *o7k.ClientSet) (*Server, error) {
 	log.Info().
 		Str("server_name", cfg.ServerName).
 		Str("server_version", cfg.ServerVersion).
 		Str("transport", cfg.Transport.Type).
 		Bool("read_only", cfg.ReadOnly).
+		Bool("dry_run", cfg.DryRun).
 		Msg("Creating MCP server")
 
-	// Create MCP server with tool capabilities
-	mcpServer := server.NewMCPServer(
-		cfg.ServerName,
-		cfg.ServerVersion,
+	policy, err := handlers.NewPolicy(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating tool policy: %w", err)
+	}
+
+	runbooks, err := prompts.Load(cfg.Prompts.Files)
+	if err != nil {
+		return nil, fmt.Errorf("loading prompts: %w", err)
+	}
+
+	// Subscriptions of a session end with it
+	methods := &methodHandler{}
+	hooks := &server.Hooks{}
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		methods.subs.addSession(session.SessionID())
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		methods.subs.removeSession(session.SessionID())
+	})
+
+	// Create MCP server with tool and resource capabilities; callers only
+	// see the tools their roles permit, every tool call is audited, checked
+	// against the policy, then runs against the cloud selected by its
+	// cloud/region arguments
+	serverOpts := []server.ServerOption{
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false),
+		server.WithPromptCapabilities(false),
+		server.WithElicitation(),
+		server.WithHooks(hooks),
+		server.WithToolFilter(handlers.ToolFilter(policy)),
+		server.WithResourceHandlerMiddleware(handlers.CloudResourceMiddleware(clients)),
+	}
+	var auditLogger *audit.Logger
+	if cfg.Audit.Enabled {
+		if auditLogger, err = audit.New(&cfg.Audit); err != nil {
+			return nil, fmt.Errorf("creating audit log: %w", err)
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(handlers.AuditMiddleware(auditLogger, policy)))
+	}
+	serverOpts = append(serverOpts,
+		server.WithToolHandlerMiddleware(handlers.PolicyMiddleware(policy)),
+		server.WithToolHandlerMiddleware(handlers.CloudMiddleware(clients)),
+		server.WithToolHandlerMiddleware(handlers.ResolveMiddleware(clients, policy)),
 	)
+	mcpServer := server.NewMCPServer(cfg.ServerName, cfg.ServerVersion, serverOpts...)
+	methods.subs = newSubscriptions(mcpServer, cfg.Subscriptions.PollInterval, cfg.Subscriptions.MaxPerSession)
+	methods.completions = &completions{completer: handlers.NewCompleter(clients, policy)}
 
 	// Create handlers
-	volumeHandler := handlers.NewVolumeHandler(osClient)
+	volumeHandler := handlers.NewVolumeHandler(clients)
+	shareHandler := handlers.NewShareHandler(clients)
+	baremetalHandler := handlers.NewBaremetalHandler(clients)
+	containerInfraHandler := handlers.NewContainerInfraHandler(clients)
+	computeHandler := handlers.NewComputeHandler(clients)
+	networkHandler := handlers.NewNetworkHandler(clients)
+	placementHandler := handlers.NewPlacementHandler(clients)
+	quotaHandler := handlers.NewQuotaHandler(clients)
+	authHandler := handlers.NewAuthHandler(clients)
+	cloudHandler := handlers.NewCloudHandler(clients)
 	handlerList := []handlers.Handler{
 		volumeHandler,
-		// Add more handlers here (NetworkHandler, ComputeHandler, etc.)
+		shareHandler,
+		baremetalHandler,
+		containerInfraHandler,
+		computeHandler,
+		networkHandler,
+		placementHandler,
+		quotaHandler,
+		authHandler,
+		cloudHandler,
 	}
 
 	// Create server instance
 	s := &Server{
-		config:     cfg,
-		osClient:   osClient,
-		mcpServer:  mcpServer,
-		handlers:   handlerList,
+		config:    cfg,
+		clients:   clients,
+		mcpServer: mcpServer,
+		handlers:  handlerList,
+		audit:     auditLogger,
+		methods:   methods,
 	}
 
-	// Register tools from all handlers
+	// Register tools from all handlers, then the resources of those exposing
+	// OpenStack objects and the runbook prompts, which depend on the
+	// registered tools
 	for _, handler := range handlerList {
-		if err := handler.RegisterTools(mcpServer, cfg.ReadOnly); err != nil {
+		if err := handler.RegisterTools(mcpServer, policy); err != nil {
 			return nil, fmt.Errorf("registering tools: %w", err)
 		}
 	}
+	for _, handler := range handlerList {
+		if resourceHandler, ok := handler.(handlers.ResourceHandler); ok {
+			if err := resourceHandler.RegisterResources(mcpServer, policy); err != nil {
+				return nil, fmt.Errorf("registering resources: %w", err)
+			}
+		}
+	}
+	prompts.Register(mcpServer, hooks, runbooks, policy)
 
 	// For HTTP transport, create the HTTP server
 	if cfg.Transport.Type == "http" {
-		s.httpServer = server.NewStreamableHTTPServer(mcpServer)
+		httpServer, err := newHTTPServer(cfg, mcpServer, clients, methods)
+		if err != nil {
+			return nil, err
+		}
+		s.httpServer = httpServer
 	}
 
 	log.Info().
@@ -90,6 +170,8 @@ func (s *Server) Start(ctx context.Context) error {
 func (s *Server) Shutdown(ctx context.Context) error {
 	log.Info().Msg("Shutting down MCP server")
 
+	s.methods.subs.watcher.Close()
+
 	// For HTTP transport, explicitly shut down the HTTP server
 	if s.httpServer != nil {
 		log.Info().Msg("Shutting down HTTP server")
@@ -100,6 +182,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		log.Info().Msg("HTTP server shutdown complete")
 	}
 
+	if s.audit != nil {
+		if err := s.audit.Close(); err != nil {
+			log.Error().Err(err).Msg("Error closing audit log")
+		}
+	}
+
 	log.Info().Msg("MCP server shutdown complete")
 	return nil
 }
@@ -108,9 +196,8 @@ func (s *Server) Shutdown(ctx context.Context) error {
 func (s *Server) startStdio(ctx context.Context) error {
 	log.Info().Msg("Starting stdio transport")
 
-	// Use mark3labs/mcp-go's built-in stdio server
 	// This is a blocking call that reads from stdin and writes to stdout
-	if err := server.ServeStdio(s.mcpServer); err != nil {
+	if err := serveStdio(s.mcpServer, s.methods); err != nil {
 		log.Error().Err(err).Msg("Stdio server error")
 		return err
 	}
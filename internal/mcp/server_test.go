@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+)
+
+// newTestServer creates a stdio server without OpenStack clients, exposing
+// sensitive tools too; tools are registered but never called
+func newTestServer(t *testing.T, readOnly bool) *Server {
+	t.Helper()
+	s, err := NewServer(&config.MCPConfig{
+		Transport:     config.TransportConfig{Type: "stdio"},
+		ServerName:    "openstack-mcp-server",
+		ServerVersion: "test",
+		ReadOnly:      readOnly,
+		Policy:        config.PolicyConfig{AllowSensitive: []string{"*"}},
+		Confirmation: config.ConfirmationConfig{
+			Destructive: true,
+			Fallback:    config.ConfirmationFallbackArgument,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("creating server: %v", err)
+	}
+	return s
+}
+
+// destructiveSuffixes end the names of tools that remove or reduce a
+// resource or access to it
+var destructiveSuffixes = []string{"_delete", "_revoke", "_shrink"}
+
+// readSuffixes end the names of tools that only read
+var readSuffixes = []string{"_list", "_get"}
+
+// writeSuffixes end the names of tools that change OpenStack state
+var writeSuffixes = []string{"_create", "_update", "_delete", "_set", "_unset", "_grant", "_revoke", "_extend", "_shrink", "_resize", "_power", "_provision"}
+
+// hasSuffix reports whether name ends with one of the suffixes
+func hasSuffix(name string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestToolAnnotations checks the annotations of every registered tool
+// against what its name says it does, so that a new tool declaring the
+// wrong behavior fails here: removals are destructive, reads are read-only,
+// changes are not, and creations are not idempotent.
+func TestToolAnnotations(t *testing.T) {
+	tools := newTestServer(t, false).mcpServer.ListTools()
+	if len(tools) == 0 {
+		t.Fatal("no tools registered")
+	}
+
+	for name, serverTool := range tools {
+		annotations := serverTool.Tool.Annotations
+		readOnly := annotations.ReadOnlyHint != nil && *annotations.ReadOnlyHint
+		destructive := annotations.DestructiveHint != nil && *annotations.DestructiveHint
+		idempotent := annotations.IdempotentHint != nil && *annotations.IdempotentHint
+
+		if hasSuffix(name, destructiveSuffixes) && !destructive {
+			t.Errorf("%s: removes a resource but is not annotated destructive", name)
+		}
+		if hasSuffix(name, readSuffixes) && !readOnly {
+			t.Errorf("%s: reads but is not annotated read-only", name)
+		}
+		if hasSuffix(name, writeSuffixes) && readOnly {
+			t.Errorf("%s: changes OpenStack state but is annotated read-only", name)
+		}
+		if strings.HasSuffix(name, "_create") && idempotent {
+			t.Errorf("%s: creates a new resource on every call but is annotated idempotent", name)
+		}
+		if readOnly && destructive {
+			t.Errorf("%s: annotated both read-only and destructive", name)
+		}
+
+		// Destructive tools are confirmed, so they take the confirm argument
+		_, confirmable := serverTool.Tool.InputSchema.Properties["confirm"]
+		if destructive != confirmable {
+			t.Errorf("%s: destructiveHint is %t, but the tool requires confirmation: %t", name, destructive, confirmable)
+		}
+	}
+}
+
+// TestReadOnlyMode checks that read-only mode registers the read-only
+// tools and only them
+func TestReadOnlyMode(t *testing.T) {
+	tools := newTestServer(t, false).mcpServer.ListTools()
+	readOnlyTools := newTestServer(t, true).mcpServer.ListTools()
+
+	for name, serverTool := range tools {
+		_, registered := readOnlyTools[name]
+		if hasSuffix(name, writeSuffixes) && registered {
+			t.Errorf("%s: changes OpenStack state but is registered in read-only mode", name)
+		}
+		if hasSuffix(name, readSuffixes) && !registered {
+			t.Errorf("%s: reads but is not registered in read-only mode", name)
+		}
+		if hint := serverTool.Tool.Annotations.ReadOnlyHint; hint != nil && *hint != registered {
+			t.Errorf("%s: readOnlyHint is %t, but registered in read-only mode: %t", name, *hint, registered)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// serveStdio serves the MCP server on stdin and stdout until stdin is closed
+// or the process is interrupted. Requests of the methods the SDK does not
+// handle are answered here, every other message is passed to the SDK's
+// stdio server.
+func serveStdio(mcpServer *server.MCPServer, methods *methodHandler) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	// The SDK and the method handler share stdout
+	stdout := &stdoutWriter{w: os.Stdout}
+
+	// The stdio server has a single session, known once it listens
+	sessionIDs := make(chan string, 1)
+	stdioServer := server.NewStdioServer(mcpServer)
+	stdioServer.SetContextFunc(func(ctx context.Context) context.Context {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			sessionIDs <- session.SessionID()
+		}
+		return ctx
+	})
+
+	stdin, forward := io.Pipe()
+	go func() {
+		forward.CloseWithError(filterStdin(ctx, os.Stdin, forward, stdout, methods, sessionIDs))
+	}()
+
+	return stdioServer.Listen(ctx, stdin, stdout)
+}
+
+// filterStdin copies the frames read from in to out, except those
+// interceptFrame keeps from the SDK, whose responses are written to stdout
+func filterStdin(ctx context.Context, in io.Reader, out io.Writer, stdout io.Writer, methods *methodHandler, sessionIDs <-chan string) error {
+	var sessionID string
+	reader := bufio.NewReader(in)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			request, response := interceptFrame(line)
+			if request != nil {
+				if sessionID == "" {
+					select {
+					case sessionID = <-sessionIDs:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				response = methods.handle(ctx, sessionID, request)
+			}
+			if response != nil {
+				if werr := writeMessage(stdout, response); werr != nil {
+					return werr
+				}
+			} else if _, werr := out.Write(line); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeMessage writes a JSON-RPC message as one line
+func writeMessage(w io.Writer, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal response")
+		return nil
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// stdoutWriter serializes writes, each of which is a whole message, and
+// declares the completions capability in the initialize response
+type stdoutWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *stdoutWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(withCompletionsCapability(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
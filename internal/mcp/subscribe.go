@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/mcp/watch"
+	"github.com/jneo8/openstack-mcp-server/internal/o7k"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+)
+
+// subscriptions answers resource subscription requests and notifies the
+// subscribed sessions when the watcher sees their resources change
+type subscriptions struct {
+	mcpServer *server.MCPServer
+	watcher   *watch.Watcher
+
+	// Sessions the MCP server registered and that have not ended; only they
+	// may subscribe
+	mu       sync.Mutex
+	sessions map[string]bool
+}
+
+// newSubscriptions creates the subscriptions of an MCP server, polling
+// subscribed resources at the given interval
+func newSubscriptions(mcpServer *server.MCPServer, interval time.Duration, maxPerSession int) *subscriptions {
+	s := &subscriptions{mcpServer: mcpServer, sessions: map[string]bool{}}
+	s.watcher = watch.New(interval, maxPerSession, s.readResource, s.notify, credentialsScope)
+	return s
+}
+
+// addSession lets a session registered by the MCP server subscribe
+func (s *subscriptions) addSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sessionID] = true
+}
+
+// removeSession forgets a session that ended and drops its subscriptions
+func (s *subscriptions) removeSession(sessionID string) {
+	s.mu.Lock()
+	delete(s.sessions, sessionID)
+	s.mu.Unlock()
+	s.watcher.RemoveSession(sessionID)
+}
+
+// hasSession reports whether a session is registered and has not ended
+func (s *subscriptions) hasSession(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions[sessionID]
+}
+
+// handle answers a resources/subscribe or resources/unsubscribe request of
+// a session
+func (s *subscriptions) handle(ctx context.Context, sessionID string, request *methodRequest) mcp.JSONRPCMessage {
+	var params mcp.SubscribeParams
+	if err := json.Unmarshal(request.Params, &params); err != nil || params.URI == "" {
+		return methodError(request.ID, mcp.INVALID_PARAMS, "missing resource uri")
+	}
+	uri := params.URI
+	if sessionID == "" {
+		return methodError(request.ID, mcp.INVALID_REQUEST, "subscriptions require a session")
+	}
+	// Over HTTP the session ID is a header the SDK does not check
+	if !s.hasSession(sessionID) {
+		return methodError(request.ID, mcp.INVALID_REQUEST, "unknown or ended session")
+	}
+
+	if request.Method == methodResourcesUnsubscribe {
+		s.watcher.Unsubscribe(sessionID, uri)
+		return mcp.NewJSONRPCResultResponse(request.ID, mcp.EmptyResult{})
+	}
+
+	if err := s.watcher.Subscribe(ctx, sessionID, uri); err != nil {
+		log.Warn().
+			Err(err).
+			Str("uri", uri).
+			Str("session_id", sessionID).
+			Msg("Failed to subscribe to resource")
+		return methodError(request.ID, mcp.INVALID_PARAMS, err.Error())
+	}
+	return mcp.NewJSONRPCResultResponse(request.ID, mcp.EmptyResult{})
+}
+
+// readResource reads a resource through the MCP server, so reads go through
+// the same middleware and permission checks as the client's own reads
+func (s *subscriptions) readResource(ctx context.Context, uri string) (string, error) {
+	message, err := json.Marshal(mcp.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId("subscription"),
+		Request: mcp.Request{Method: string(mcp.MethodResourcesRead)},
+		Params:  mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch response := s.mcpServer.HandleMessage(ctx, message).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := response.Result.(mcp.ReadResourceResult)
+		if !ok {
+			return "", fmt.Errorf("unexpected result reading %s", uri)
+		}
+		var content strings.Builder
+		for _, contents := range result.Contents {
+			if text, ok := contents.(mcp.TextResourceContents); ok {
+				content.WriteString(text.Text)
+			}
+		}
+		return content.String(), nil
+	case mcp.JSONRPCError:
+		return "", errors.New(response.Error.Message)
+	default:
+		return "", fmt.Errorf("unexpected response reading %s", uri)
+	}
+}
+
+// notify sends a resource updated notification to a session
+func (s *subscriptions) notify(sessionID, uri string) bool {
+	err := s.mcpServer.SendNotificationToSpecificClient(sessionID,
+		mcp.MethodNotificationResourceUpdated,
+		map[string]any{"uri": uri},
+	)
+	if errors.Is(err, server.ErrSessionNotFound) {
+		return false
+	}
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("uri", uri).
+			Str("session_id", sessionID).
+			Msg("Failed to send resource updated notification")
+	}
+	return true
+}
+
+// credentialsScope separates the polls of callers using different
+// per-request credentials
+func credentialsScope(ctx context.Context) string {
+	if creds, ok := o7k.RequestCredentialsFromContext(ctx); ok {
+		return creds.Key()
+	}
+	return ""
+}
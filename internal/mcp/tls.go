@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// tlsReloader serves the HTTP transport's certificate and client CA bundle,
+// reloading them when the files change so rotated certificates, e.g. from
+// cert-manager, apply to new connections without a restart
+type tlsReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	config   *tls.Config
+}
+
+// newTLSReloader loads the configured certificate and client CA bundle
+func newTLSReloader(cfg *config.TransportConfig) (*tlsReloader, error) {
+	r := &tlsReloader{
+		certFile:     cfg.TLSCertFile,
+		keyFile:      cfg.TLSKeyFile,
+		clientCAFile: cfg.TLSClientCAFile,
+	}
+	if _, err := r.current(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns the server TLS configuration, resolved per connection
+func (r *tlsReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current()
+		},
+	}
+}
+
+// current returns the TLS configuration, rebuilding it when a file changed.
+// A failed reload, e.g. while a secret is half written, keeps the previous one.
+func (r *tlsReloader) current() (*tls.Config, error) {
+	modTimes, err := r.stat()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil && r.config != nil && modTimes == r.modTimes {
+		return r.config, nil
+	}
+	if err == nil {
+		var cfg *tls.Config
+		if cfg, err = r.load(); err == nil {
+			if r.config != nil {
+				log.Info().Str("tls_cert_file", r.certFile).Msg("Reloaded HTTP TLS certificate")
+			}
+			r.config = cfg
+			r.modTimes = modTimes
+			return cfg, nil
+		}
+	}
+
+	if r.config == nil {
+		return nil, err
+	}
+	log.Error().Err(err).Msg("Failed to reload HTTP TLS certificate, keeping the previous one")
+	return r.config, nil
+}
+
+// stat returns the modification times of the certificate, key and client CA files
+func (r *tlsReloader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, fmt.Errorf("reading TLS file: %w", err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// load builds the TLS configuration from the files
+func (r *tlsReloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	// Mutual TLS: only clients with a certificate signed by the CA may connect
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", r.clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
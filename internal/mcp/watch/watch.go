@@ -0,0 +1,276 @@
+// Package watch polls subscribed MCP resources and tells subscribers when
+// they change
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReadFunc reads the current content of a resource
+type ReadFunc func(ctx context.Context, uri string) (string, error)
+
+// NotifyFunc tells a session that a resource changed. It returns false when
+// the session is gone, which drops its subscriptions.
+type NotifyFunc func(sessionID, uri string) bool
+
+// ScopeFunc returns the key of the credentials a read runs with; sessions
+// share a poll only when they read with the same credentials
+type ScopeFunc func(ctx context.Context) string
+
+// maxFailedPolls is how many polls in a row may fail before a resource is
+// no longer polled, e.g. once deleted or once the subscribers' token expired
+const maxFailedPolls = 5
+
+// Watcher polls each subscribed resource once per interval, however many
+// sessions subscribed to it, and notifies them when its content changes.
+// Polling of a resource stops when its last subscriber leaves, or after
+// maxFailedPolls failed reads in a row.
+type Watcher struct {
+	interval      time.Duration
+	maxPerSession int
+	read          ReadFunc
+	notify        NotifyFunc
+	scope         ScopeFunc
+
+	mu      sync.Mutex
+	watches map[watchKey]*watch
+}
+
+// watchKey identifies a shared poll
+type watchKey struct {
+	uri   string
+	scope string
+}
+
+// watch is the poll of one resource
+type watch struct {
+	key      watchKey
+	stop     context.Context // Cancelled when polling stops
+	cancel   context.CancelFunc
+	sessions map[string]context.Context // Of each subscribe request, carrying its credentials
+	reader   string                     // Session whose context reads the resource
+	content  string
+	failures int // Failed polls in a row
+}
+
+// New creates a watcher polling at the given interval, letting each
+// session hold at most maxPerSession subscriptions
+func New(interval time.Duration, maxPerSession int, read ReadFunc, notify NotifyFunc, scope ScopeFunc) *Watcher {
+	return &Watcher{
+		interval:      interval,
+		maxPerSession: maxPerSession,
+		read:          read,
+		notify:        notify,
+		scope:         scope,
+		watches:       map[watchKey]*watch{},
+	}
+}
+
+// Subscribe subscribes a session to a resource. The resource is read first
+// with the subscriber's context, so unknown resources and callers not
+// permitted to read them are rejected, as are subscriptions beyond the
+// session's limit. The resource is polled with the context of the latest
+// subscriber still subscribed.
+func (w *Watcher) Subscribe(ctx context.Context, sessionID, uri string) error {
+	content, err := w.read(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	key := watchKey{uri: uri, scope: w.scope(ctx)}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Polls outlive the subscribe request, but keep its values
+	subscriberCtx := context.WithoutCancel(ctx)
+	existing, ok := w.watches[key]
+	if ok {
+		if _, subscribed := existing.sessions[sessionID]; subscribed {
+			existing.sessions[sessionID] = subscriberCtx
+			existing.reader = sessionID
+			return nil
+		}
+	}
+	if w.countLocked(sessionID) >= w.maxPerSession {
+		return fmt.Errorf("session already holds the maximum of %d subscriptions, unsubscribe from a resource first", w.maxPerSession)
+	}
+
+	if ok {
+		existing.sessions[sessionID] = subscriberCtx
+		existing.reader = sessionID
+		log.Debug().
+			Str("uri", uri).
+			Str("session_id", sessionID).
+			Int("subscribers", len(existing.sessions)).
+			Msg("Subscribed to resource")
+		return nil
+	}
+
+	stop, cancel := context.WithCancel(context.Background())
+	wt := &watch{
+		key:      key,
+		stop:     stop,
+		cancel:   cancel,
+		sessions: map[string]context.Context{sessionID: subscriberCtx},
+		reader:   sessionID,
+		content:  content,
+	}
+	w.watches[key] = wt
+	go w.poll(wt)
+
+	log.Info().
+		Str("uri", uri).
+		Str("session_id", sessionID).
+		Dur("interval", w.interval).
+		Msg("Watching resource")
+	return nil
+}
+
+// Unsubscribe removes the subscriptions of a session to a resource
+func (w *Watcher) Unsubscribe(sessionID, uri string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key, wt := range w.watches {
+		if key.uri == uri {
+			w.removeLocked(wt, sessionID)
+		}
+	}
+}
+
+// RemoveSession removes all subscriptions of a session
+func (w *Watcher) RemoveSession(sessionID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, wt := range w.watches {
+		w.removeLocked(wt, sessionID)
+	}
+}
+
+// Close stops all polls
+func (w *Watcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key, wt := range w.watches {
+		wt.cancel()
+		delete(w.watches, key)
+	}
+}
+
+// countLocked returns the number of subscriptions a session holds
+func (w *Watcher) countLocked(sessionID string) int {
+	count := 0
+	for _, wt := range w.watches {
+		if _, ok := wt.sessions[sessionID]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// removeLocked removes a session from a watch, stopping it when it was the
+// last subscriber. When the session's context read the resource, another
+// subscriber's takes over, so no context is used after its session left.
+func (w *Watcher) removeLocked(wt *watch, sessionID string) {
+	if _, ok := wt.sessions[sessionID]; !ok {
+		return
+	}
+	delete(wt.sessions, sessionID)
+	if len(wt.sessions) == 0 {
+		w.stopLocked(wt)
+		return
+	}
+	if wt.reader == sessionID {
+		for remaining := range wt.sessions {
+			wt.reader = remaining
+			break
+		}
+		log.Debug().
+			Str("uri", wt.key.uri).
+			Str("session_id", wt.reader).
+			Msg("Polling resource with another subscriber's context")
+	}
+}
+
+// stopLocked stops polling a resource
+func (w *Watcher) stopLocked(wt *watch) {
+	wt.cancel()
+	delete(w.watches, wt.key)
+	log.Info().Str("uri", wt.key.uri).Msg("Stopped watching resource")
+}
+
+// poll reads a resource every interval until its watch is stopped
+func (w *Watcher) poll(wt *watch) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wt.stop.Done():
+			return
+		case <-ticker.C:
+		}
+
+		w.mu.Lock()
+		if wt.stop.Err() != nil {
+			w.mu.Unlock()
+			return
+		}
+		readCtx, cancel := context.WithCancel(wt.sessions[wt.reader])
+		w.mu.Unlock()
+		stopRead := context.AfterFunc(wt.stop, cancel)
+		content, err := w.read(readCtx, wt.key.uri)
+		stopRead()
+		cancel()
+
+		w.mu.Lock()
+		if wt.stop.Err() != nil {
+			w.mu.Unlock()
+			return
+		}
+		if err != nil {
+			// A deleted resource reads as an error; report it once, as a
+			// change, and stop polling when the error persists
+			log.Debug().Err(err).Str("uri", wt.key.uri).Msg("Failed to poll resource")
+			content = "error: " + err.Error()
+			wt.failures++
+		} else {
+			wt.failures = 0
+		}
+		changed := content != wt.content
+		wt.content = content
+		failed := wt.failures >= maxFailedPolls
+		var sessions []string
+		if changed || failed {
+			for sessionID := range wt.sessions {
+				sessions = append(sessions, sessionID)
+			}
+		}
+		if failed {
+			log.Warn().
+				Err(err).
+				Str("uri", wt.key.uri).
+				Int("failed_polls", wt.failures).
+				Msg("Resource keeps failing to read, dropping its subscriptions")
+			w.stopLocked(wt)
+		}
+		w.mu.Unlock()
+
+		for _, sessionID := range sessions {
+			if !w.notify(sessionID, wt.key.uri) {
+				log.Debug().
+					Str("session_id", sessionID).
+					Str("uri", wt.key.uri).
+					Msg("Subscriber gone, dropping its subscriptions")
+				w.RemoveSession(sessionID)
+			}
+		}
+		if failed {
+			return
+		}
+	}
+}
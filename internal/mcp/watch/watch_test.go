@@ -0,0 +1,113 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sessionKey stores the session a subscribe request came from in a context
+type sessionKey struct{}
+
+// recorder records the sessions whose context read a resource and the
+// notifications sent
+type recorder struct {
+	mu       sync.Mutex
+	readers  []string
+	notified []string
+	err      error // Returned by reads
+}
+
+func (r *recorder) read(ctx context.Context, uri string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, _ := ctx.Value(sessionKey{}).(string)
+	r.readers = append(r.readers, session)
+	return "content", r.err
+}
+
+func (r *recorder) notify(sessionID, uri string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notified = append(r.notified, sessionID)
+	return true
+}
+
+// lastReader returns the session whose context read the resource last
+func (r *recorder) lastReader() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.readers[len(r.readers)-1]
+}
+
+// subscribe subscribes a session with a context naming it
+func subscribe(t *testing.T, w *Watcher, sessionID string) {
+	t.Helper()
+	ctx := context.WithValue(context.Background(), sessionKey{}, sessionID)
+	if err := w.Subscribe(ctx, sessionID, "openstack://volumes/1"); err != nil {
+		t.Fatalf("subscribing %s: %v", sessionID, err)
+	}
+}
+
+// TestWatcherReaderHandover checks that polls read with the latest
+// subscriber's context, and never with that of a session that left
+func TestWatcherReaderHandover(t *testing.T) {
+	r := &recorder{}
+	w := New(5*time.Millisecond, 10, r.read, r.notify, func(context.Context) string { return "" })
+	defer w.Close()
+
+	subscribe(t, w, "a")
+	subscribe(t, w, "b")
+	time.Sleep(20 * time.Millisecond)
+	if got := r.lastReader(); got != "b" {
+		t.Errorf("polled with the context of %q, want the latest subscriber b", got)
+	}
+
+	w.Unsubscribe("b", "openstack://volumes/1")
+	time.Sleep(20 * time.Millisecond)
+	if got := r.lastReader(); got != "a" {
+		t.Errorf("polled with the context of %q after b unsubscribed, want a", got)
+	}
+}
+
+// TestWatcherDropsFailingResource checks that a resource that keeps failing
+// to read is no longer polled, after telling its subscribers
+func TestWatcherDropsFailingResource(t *testing.T) {
+	r := &recorder{}
+	w := New(time.Millisecond, 10, r.read, r.notify, func(context.Context) string { return "" })
+	defer w.Close()
+
+	subscribe(t, w, "a")
+	r.mu.Lock()
+	r.err = errors.New("authentication required")
+	r.mu.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		w.mu.Lock()
+		watching := len(w.watches)
+		w.mu.Unlock()
+		r.mu.Lock()
+		notified := len(r.notified)
+		r.mu.Unlock()
+		if watching == 0 && notified >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("failing resource still polled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Told once when the read started failing, once when dropped
+	if len(r.notified) != 2 {
+		t.Errorf("notified %v, want a at the first failure and when dropped", r.notified)
+	}
+	if reads := len(r.readers); reads != maxFailedPolls+1 {
+		t.Errorf("%d reads, want the subscribe read and %d failed polls", reads, maxFailedPolls)
+	}
+}
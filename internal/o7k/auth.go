@@ -0,0 +1,184 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// TokenInfo describes the Keystone token currently used by the client
+type TokenInfo struct {
+	AuthType    string    `json:"auth_type"`
+	UserID      string    `json:"user_id,omitempty"`
+	UserName    string    `json:"user_name,omitempty"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	ProjectName string    `json:"project_name,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	ExpiresIn   string    `json:"expires_in"`
+	CanReauth   bool      `json:"can_reauth"`
+}
+
+// tokenResult is implemented by both token create and token get results
+type tokenResult interface {
+	ExtractToken() (*tokens.Token, error)
+	ExtractUser() (*tokens.User, error)
+	ExtractProject() (*tokens.Project, error)
+}
+
+// catalogResult is implemented by token results carrying a service catalog
+type catalogResult interface {
+	ExtractServiceCatalog() (*tokens.ServiceCatalog, error)
+}
+
+// canReauth reports whether the credentials of an auth type can be replayed
+// to obtain a new token. Tokens cannot outlive themselves and TOTP passcodes
+// are single use.
+func canReauth(authType string) bool {
+	return authType == config.AuthTypePassword || authType == config.AuthTypeApplicationCredential
+}
+
+// enableReauthLogging wraps the provider's reauth function to log each
+// refresh and the expiry of the new token
+func enableReauthLogging(provider *gophercloud.ProviderClient) {
+	if provider.ReauthFunc == nil {
+		return
+	}
+
+	reauth := provider.ReauthFunc
+	provider.ReauthFunc = func(ctx context.Context) error {
+		log.Info().Msg("Re-authenticating with OpenStack")
+		if err := reauth(ctx); err != nil {
+			log.Error().Err(err).Msg("Re-authentication failed")
+			return err
+		}
+		logTokenExpiry(provider)
+		return nil
+	}
+}
+
+// logTokenExpiry logs when the current token expires
+func logTokenExpiry(provider *gophercloud.ProviderClient) {
+	result, ok := provider.GetAuthResult().(tokenResult)
+	if !ok {
+		return
+	}
+	token, err := result.ExtractToken()
+	if err != nil {
+		return
+	}
+
+	event := log.Info()
+	if provider.ReauthFunc == nil {
+		// Nothing will renew the token; make its end of life visible
+		event = log.Warn().Bool("can_reauth", false)
+	}
+	event.
+		Time("expires_at", token.ExpiresAt).
+		Dur("expires_in", time.Until(token.ExpiresAt).Round(time.Second)).
+		Msg("Obtained OpenStack token")
+}
+
+// TokenInfo returns details about the token currently in use
+func (c *Client) TokenInfo() (*TokenInfo, error) {
+	result, ok := c.provider.GetAuthResult().(tokenResult)
+	if !ok {
+		return nil, fmt.Errorf("no token information available")
+	}
+
+	token, err := result.ExtractToken()
+	if err != nil {
+		return nil, fmt.Errorf("extracting token: %w", err)
+	}
+
+	info := &TokenInfo{
+		AuthType:  c.authType,
+		ExpiresAt: token.ExpiresAt,
+		ExpiresIn: time.Until(token.ExpiresAt).Round(time.Second).String(),
+		CanReauth: c.provider.ReauthFunc != nil,
+	}
+	if user, err := result.ExtractUser(); err == nil && user != nil {
+		info.UserID = user.ID
+		info.UserName = user.Name
+	}
+	if project, err := result.ExtractProject(); err == nil && project != nil {
+		info.ProjectID = project.ID
+		info.ProjectName = project.Name
+	}
+
+	return info, nil
+}
+
+// Reauthenticate forces a new token to be issued, e.g. after role changes.
+// Concurrent calls and 401-triggered refreshes share a single request.
+func (c *Client) Reauthenticate(ctx context.Context) (*TokenInfo, error) {
+	if c.provider.ReauthFunc == nil {
+		return nil, fmt.Errorf("re-authentication is not possible with %s authentication", c.authType)
+	}
+
+	// Token requests pass dry runs so that other tools can re-authenticate;
+	// a forced refresh is only captured, without the credentials
+	if dryRun, ok := ctx.Value(dryRunContextKey{}).(*DryRun); ok {
+		dryRun.add(DryRunRequest{
+			Method: http.MethodPost,
+			URL:    strings.TrimSuffix(c.provider.IdentityEndpoint, "/") + "/auth/tokens",
+		})
+		return nil, ErrDryRun
+	}
+
+	// An empty previous token forces the refresh unconditionally
+	if err := c.provider.Reauthenticate(ctx, ""); err != nil {
+		return nil, fmt.Errorf("re-authenticating: %w", err)
+	}
+
+	return c.TokenInfo()
+}
+
+// scopedProjectID returns the project of the Keystone v3 token, falling back
+// to the configured project ID
+func scopedProjectID(provider *gophercloud.ProviderClient, cfg *config.OpenStackConfig) string {
+	if result, ok := provider.GetAuthResult().(tokenResult); ok {
+		if project, err := result.ExtractProject(); err == nil && project != nil {
+			return project.ID
+		}
+	}
+	return cfg.ProjectID
+}
+
+// checkRegion returns an error unless the service catalog of the client's
+// token has endpoints in region. Tokens without a catalog are not checked.
+func (c *Client) checkRegion(region string) error {
+	result, ok := c.provider.GetAuthResult().(catalogResult)
+	if !ok {
+		return nil
+	}
+	catalog, err := result.ExtractServiceCatalog()
+	if err != nil || catalog == nil || len(catalog.Entries) == 0 {
+		return nil
+	}
+
+	return catalogHasRegion(catalog, region)
+}
+
+// catalogHasRegion returns an error listing the catalog's regions unless
+// one of its endpoints is in region
+func catalogHasRegion(catalog *tokens.ServiceCatalog, region string) error {
+	regions := map[string]bool{}
+	for _, entry := range catalog.Entries {
+		for _, endpoint := range entry.Endpoints {
+			if endpoint.Region == region || endpoint.RegionID == region {
+				return nil
+			}
+			regions[endpoint.Region] = true
+		}
+	}
+	return fmt.Errorf("unknown region %q, available: %v", region, slices.Sorted(maps.Keys(regions)))
+}
@@ -0,0 +1,354 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	"github.com/rs/zerolog/log"
+)
+
+// BaremetalNode represents an OpenStack bare metal (Ironic) node
+type BaremetalNode struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	PowerState           string         `json:"power_state"`            // power on, power off, etc.
+	TargetPowerState     string         `json:"target_power_state"`     // set while a power transition is in progress
+	ProvisionState       string         `json:"provision_state"`        // enroll, manageable, available, active, etc.
+	TargetProvisionState string         `json:"target_provision_state"` // set while a provisioning transition is in progress
+	Maintenance          bool           `json:"maintenance"`
+	MaintenanceReason    string         `json:"maintenance_reason,omitempty"`
+	Fault                string         `json:"fault,omitempty"`
+	LastError            string         `json:"last_error,omitempty"`
+	Driver               string         `json:"driver"`
+	ResourceClass        string         `json:"resource_class"`
+	ConductorGroup       string         `json:"conductor_group,omitempty"`
+	Conductor            string         `json:"conductor,omitempty"`
+	InstanceID           string         `json:"instance_id,omitempty"`
+	Owner                string         `json:"owner,omitempty"`
+	Properties           map[string]any `json:"properties,omitempty"`
+	Traits               []string       `json:"traits,omitempty"`
+	CreatedAt            string         `json:"created_at"`
+	UpdatedAt            string         `json:"updated_at"`
+	ProvisionUpdatedAt   string         `json:"provision_updated_at"`
+}
+
+// BaremetalPort represents a network port of a bare metal node
+type BaremetalPort struct {
+	ID                  string         `json:"id"`
+	Address             string         `json:"address"` // MAC address
+	NodeID              string         `json:"node_id"`
+	PortGroupID         string         `json:"portgroup_id,omitempty"`
+	PXEEnabled          bool           `json:"pxe_enabled"`
+	PhysicalNetwork     string         `json:"physical_network,omitempty"`
+	LocalLinkConnection map[string]any `json:"local_link_connection,omitempty"`
+}
+
+// BaremetalInterfaceValidation is the validation result of a single node driver interface
+type BaremetalInterfaceValidation struct {
+	Interface string `json:"interface"`
+	Result    bool   `json:"result"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ListBaremetalNodesOpts contains filters for listing bare metal nodes
+type ListBaremetalNodesOpts struct {
+	ProvisionState string `json:"provision_state,omitempty"`
+	PowerState     string `json:"power_state,omitempty"` // Filtered client-side, the API has no such filter
+	Maintenance    *bool  `json:"maintenance,omitempty"`
+	ResourceClass  string `json:"resource_class,omitempty"`
+}
+
+// BaremetalCleanStep is a step of manual cleaning, run by a driver interface
+// of the node
+type BaremetalCleanStep struct {
+	Interface string         `json:"interface"` // bios, deploy, firmware, management, power or raid
+	Step      string         `json:"step"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+// baremetalPowerTargets maps the supported power actions to Ironic target power states
+var baremetalPowerTargets = map[string]nodes.TargetPowerState{
+	"on":     nodes.PowerOn,
+	"off":    nodes.PowerOff,
+	"reboot": nodes.Rebooting,
+}
+
+// baremetalProvisionTargets maps the supported provisioning actions to Ironic targets
+var baremetalProvisionTargets = map[string]nodes.TargetProvisionState{
+	"manage":  nodes.TargetManage,
+	"provide": nodes.TargetProvide,
+	"deploy":  nodes.TargetActive,
+	"clean":   nodes.TargetClean,
+}
+
+// ListBaremetalNodes lists bare metal nodes matching the given filters
+func (c *Client) ListBaremetalNodes(ctx context.Context, opts ListBaremetalNodesOpts) ([]BaremetalNode, error) {
+	if c.baremetalV1 == nil {
+		return nil, fmt.Errorf("bare metal client not initialized")
+	}
+
+	log.Debug().
+		Str("provision_state", opts.ProvisionState).
+		Str("power_state", opts.PowerState).
+		Msg("Listing bare metal nodes")
+
+	listOpts := nodes.ListOpts{
+		ProvisionState: nodes.ProvisionState(opts.ProvisionState),
+		ResourceClass:  opts.ResourceClass,
+	}
+
+	allPages, err := nodes.ListDetail(c.baremetalV1, listOpts).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing bare metal nodes: %w", err)
+	}
+
+	allNodes, err := nodes.ExtractNodes(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting bare metal nodes: %w", err)
+	}
+
+	result := make([]BaremetalNode, 0, len(allNodes))
+	for _, node := range allNodes {
+		if opts.PowerState != "" && node.PowerState != opts.PowerState {
+			continue
+		}
+		if opts.Maintenance != nil && node.Maintenance != *opts.Maintenance {
+			continue
+		}
+		result = append(result, *convertBaremetalNode(&node))
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed bare metal nodes")
+	return result, nil
+}
+
+// GetBaremetalNode retrieves a bare metal node by UUID or name
+func (c *Client) GetBaremetalNode(ctx context.Context, nodeID string) (*BaremetalNode, error) {
+	if c.baremetalV1 == nil {
+		return nil, fmt.Errorf("bare metal client not initialized")
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Msg("Getting bare metal node")
+
+	node, err := nodes.Get(ctx, c.baremetalV1, nodeID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting bare metal node %s: %w", nodeID, err)
+	}
+
+	return convertBaremetalNode(node), nil
+}
+
+// ListBaremetalPorts lists the ports of a bare metal node
+func (c *Client) ListBaremetalPorts(ctx context.Context, nodeID string) ([]BaremetalPort, error) {
+	if c.baremetalV1 == nil {
+		return nil, fmt.Errorf("bare metal client not initialized")
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Msg("Listing bare metal ports")
+
+	allPages, err := ports.ListDetail(c.baremetalV1, ports.ListOpts{Node: nodeID}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing ports of bare metal node %s: %w", nodeID, err)
+	}
+
+	allPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting bare metal ports: %w", err)
+	}
+
+	result := make([]BaremetalPort, len(allPorts))
+	for i, port := range allPorts {
+		result[i] = BaremetalPort{
+			ID:                  port.UUID,
+			Address:             port.Address,
+			NodeID:              port.NodeUUID,
+			PortGroupID:         port.PortGroupUUID,
+			PXEEnabled:          port.PXEEnabled,
+			PhysicalNetwork:     port.PhysicalNetwork,
+			LocalLinkConnection: port.LocalLinkConnection,
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateBaremetalNode validates the driver interfaces of a bare metal node
+func (c *Client) ValidateBaremetalNode(ctx context.Context, nodeID string) ([]BaremetalInterfaceValidation, error) {
+	if c.baremetalV1 == nil {
+		return nil, fmt.Errorf("bare metal client not initialized")
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Msg("Validating bare metal node")
+
+	v, err := nodes.Validate(ctx, c.baremetalV1, nodeID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("validating bare metal node %s: %w", nodeID, err)
+	}
+
+	interfaces := []struct {
+		name string
+		nodes.DriverValidation
+	}{
+		{"bios", v.BIOS},
+		{"boot", v.Boot},
+		{"console", v.Console},
+		{"deploy", v.Deploy},
+		{"firmware", v.Firmware},
+		{"inspect", v.Inspect},
+		{"management", v.Management},
+		{"network", v.Network},
+		{"power", v.Power},
+		{"raid", v.RAID},
+		{"rescue", v.Rescue},
+		{"storage", v.Storage},
+	}
+
+	result := make([]BaremetalInterfaceValidation, len(interfaces))
+	for i, iface := range interfaces {
+		result[i] = BaremetalInterfaceValidation{
+			Interface: iface.name,
+			Result:    iface.Result,
+			Reason:    iface.Reason,
+		}
+	}
+
+	return result, nil
+}
+
+// SetBaremetalPowerState powers a bare metal node on or off, or reboots it
+func (c *Client) SetBaremetalPowerState(ctx context.Context, nodeID, action string) error {
+	if c.baremetalV1 == nil {
+		return fmt.Errorf("bare metal client not initialized")
+	}
+
+	target, ok := baremetalPowerTargets[action]
+	if !ok {
+		return fmt.Errorf("unsupported power action %q", action)
+	}
+
+	log.Info().
+		Str("node_id", nodeID).
+		Str("target", string(target)).
+		Msg("Changing bare metal node power state")
+
+	err := nodes.ChangePowerState(ctx, c.baremetalV1, nodeID, nodes.PowerStateOpts{Target: target}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("changing power state of bare metal node %s: %w", nodeID, err)
+	}
+
+	return nil
+}
+
+// SetBaremetalMaintenance puts a bare metal node into maintenance mode with the given reason
+func (c *Client) SetBaremetalMaintenance(ctx context.Context, nodeID, reason string) error {
+	if c.baremetalV1 == nil {
+		return fmt.Errorf("bare metal client not initialized")
+	}
+
+	log.Info().
+		Str("node_id", nodeID).
+		Str("reason", reason).
+		Msg("Setting bare metal node maintenance")
+
+	err := nodes.SetMaintenance(ctx, c.baremetalV1, nodeID, nodes.MaintenanceOpts{Reason: reason}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("setting maintenance on bare metal node %s: %w", nodeID, err)
+	}
+
+	return nil
+}
+
+// UnsetBaremetalMaintenance takes a bare metal node out of maintenance mode
+func (c *Client) UnsetBaremetalMaintenance(ctx context.Context, nodeID string) error {
+	if c.baremetalV1 == nil {
+		return fmt.Errorf("bare metal client not initialized")
+	}
+
+	log.Info().
+		Str("node_id", nodeID).
+		Msg("Unsetting bare metal node maintenance")
+
+	err := nodes.UnsetMaintenance(ctx, c.baremetalV1, nodeID).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("unsetting maintenance on bare metal node %s: %w", nodeID, err)
+	}
+
+	return nil
+}
+
+// SetBaremetalProvisionState requests a provision state transition (manage,
+// provide, deploy, clean). Manual cleaning runs the given clean steps, which
+// only the clean action takes and requires.
+func (c *Client) SetBaremetalProvisionState(ctx context.Context, nodeID, action string, cleanSteps []BaremetalCleanStep) error {
+	if c.baremetalV1 == nil {
+		return fmt.Errorf("bare metal client not initialized")
+	}
+
+	target, ok := baremetalProvisionTargets[action]
+	if !ok {
+		return fmt.Errorf("unsupported provision action %q", action)
+	}
+	if target == nodes.TargetClean && len(cleanSteps) == 0 {
+		return fmt.Errorf("the clean action requires clean steps")
+	}
+	if target != nodes.TargetClean && len(cleanSteps) > 0 {
+		return fmt.Errorf("clean steps only apply to the clean action")
+	}
+
+	log.Info().
+		Str("node_id", nodeID).
+		Str("target", string(target)).
+		Int("clean_steps", len(cleanSteps)).
+		Msg("Changing bare metal node provision state")
+
+	opts := nodes.ProvisionStateOpts{Target: target}
+	for _, step := range cleanSteps {
+		opts.CleanSteps = append(opts.CleanSteps, nodes.CleanStep{
+			Interface: nodes.StepInterface(step.Interface),
+			Step:      step.Step,
+			Args:      step.Args,
+		})
+	}
+
+	err := nodes.ChangeProvisionState(ctx, c.baremetalV1, nodeID, opts).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("changing provision state of bare metal node %s: %w", nodeID, err)
+	}
+
+	return nil
+}
+
+// convertBaremetalNode converts a Gophercloud node to our BaremetalNode type
+func convertBaremetalNode(node *nodes.Node) *BaremetalNode {
+	return &BaremetalNode{
+		ID:                   node.UUID,
+		Name:                 node.Name,
+		PowerState:           node.PowerState,
+		TargetPowerState:     node.TargetPowerState,
+		ProvisionState:       node.ProvisionState,
+		TargetProvisionState: node.TargetProvisionState,
+		Maintenance:          node.Maintenance,
+		MaintenanceReason:    node.MaintenanceReason,
+		Fault:                node.Fault,
+		LastError:            node.LastError,
+		Driver:               node.Driver,
+		ResourceClass:        node.ResourceClass,
+		ConductorGroup:       node.ConductorGroup,
+		Conductor:            node.Conductor,
+		InstanceID:           node.InstanceUUID,
+		Owner:                node.Owner,
+		Properties:           node.Properties,
+		Traits:               node.Traits,
+		CreatedAt:            node.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:            node.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ProvisionUpdatedAt:   node.ProvisionUpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
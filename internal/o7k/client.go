@@ -3,8 +3,10 @@ package o7k
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack"
@@ -14,30 +16,47 @@ import (
 
 // Client represents an OpenStack client with authenticated connections
 type Client struct {
-	provider        *gophercloud.ProviderClient
-	blockStorageV3  *gophercloud.ServiceClient
-	config          *config.OpenStackConfig
+	provider            *gophercloud.ProviderClient
+	blockStorageV3      *gophercloud.ServiceClient
+	sharedFileSystemsV2 *gophercloud.ServiceClient
+	baremetalV1         *gophercloud.ServiceClient
+	containerInfraV1    *gophercloud.ServiceClient
+	placementV1         *gophercloud.ServiceClient
+	computeV2           *gophercloud.ServiceClient
+	networkV2           *gophercloud.ServiceClient
+	projectID           string // Project the token is scoped to
+	authType            string
+	config              *config.OpenStackConfig
 }
 
+// sharedFileSystemsMicroversion is the Manila API microversion used for share
+// actions (extend, shrink, access rules) and export locations
+const sharedFileSystemsMicroversion = "2.9"
+
+// baremetalMicroversion is the Ironic API microversion used for node
+// listing (traits, conductor groups) and state transitions
+const baremetalMicroversion = "1.49"
+
+// containerInfraMicroversion is the Magnum API microversion used for node
+// groups and node group resize
+const containerInfraMicroversion = "1.10"
+
+// placementMicroversion is the Placement API microversion used for provider
+// trees, trait filters and consumer project/user in allocations
+const placementMicroversion = "1.18"
+
 // NewClient creates a new OpenStack client with authentication
 func NewClient(cfg *config.OpenStackConfig) (*Client, error) {
-	// Create authentication options
-	authOpts := gophercloud.AuthOptions{
-		IdentityEndpoint: cfg.AuthURL,
-		Username:         cfg.Username,
-		Password:         cfg.Password,
-		DomainName:       cfg.UserDomain,
-		TenantName:       cfg.ProjectName,
-		TenantID:         cfg.ProjectID,
-	}
-
-	// If project domain is specified, use scoped auth
-	if cfg.ProjectDomain != "" {
-		authOpts.DomainName = cfg.ProjectDomain
+	authType, err := cfg.ResolveAuthType()
+	if err != nil {
+		return nil, fmt.Errorf("resolving auth type: %w", err)
 	}
+	authOpts := buildAuthOptions(cfg, authType)
+	authOpts.AllowReauth = canReauth(authType)
 
 	log.Debug().
 		Str("auth_url", cfg.AuthURL).
+		Str("auth_type", authType).
 		Str("username", cfg.Username).
 		Str("project_name", cfg.ProjectName).
 		Str("region", cfg.Region).
@@ -45,18 +64,13 @@ func NewClient(cfg *config.OpenStackConfig) (*Client, error) {
 		Msg("Authenticating with OpenStack")
 
 	// Configure HTTP client with TLS settings
-	httpClient := http.Client{
-		Timeout: cfg.Timeout,
+	transport, err := newHTTPTransport(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
 	}
-
-	// Disable SSL verification if configured (for development/self-signed certs)
-	if !cfg.VerifySSL {
-		log.Warn().Msg("SSL verification disabled - not recommended for production")
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		}
+	httpClient := http.Client{
+		Transport: &dryRunTransport{base: &requestIDTransport{base: transport}},
+		Timeout:   cfg.Timeout,
 	}
 
 	// Create provider client with custom HTTP client
@@ -69,6 +83,8 @@ func NewClient(cfg *config.OpenStackConfig) (*Client, error) {
 	// Set HTTP client and configure provider
 	provider.HTTPClient = httpClient
 	provider.MaxBackoffRetries = uint(cfg.MaxRetries)
+	// Tool calls run concurrently; serialize token refreshes
+	provider.UseTokenLock()
 
 	// Authenticate
 	err = openstack.Authenticate(ctx, provider, authOpts)
@@ -77,10 +93,14 @@ func NewClient(cfg *config.OpenStackConfig) (*Client, error) {
 	}
 
 	log.Info().Msg("Successfully authenticated with OpenStack")
+	enableReauthLogging(provider)
+	logTokenExpiry(provider)
 
 	client := &Client{
-		provider: provider,
-		config:   cfg,
+		provider:  provider,
+		projectID: scopedProjectID(provider, cfg),
+		authType:  authType,
+		config:    cfg,
 	}
 
 	// Initialize Block Storage (Cinder) v3 client
@@ -88,9 +108,134 @@ func NewClient(cfg *config.OpenStackConfig) (*Client, error) {
 		return nil, fmt.Errorf("failed to initialize block storage client: %w", err)
 	}
 
+	// Initialize optional services; not every cloud deploys them, so a missing
+	// endpoint only disables the related tools
+	if err := client.initSharedFileSystems(); err != nil {
+		log.Warn().Err(err).Msg("Shared file systems service unavailable")
+	}
+	if err := client.initBaremetal(); err != nil {
+		log.Warn().Err(err).Msg("Bare metal service unavailable")
+	}
+	if err := client.initContainerInfra(); err != nil {
+		log.Warn().Err(err).Msg("Container infra service unavailable")
+	}
+	if err := client.initPlacement(); err != nil {
+		log.Warn().Err(err).Msg("Placement service unavailable")
+	}
+	if err := client.initCompute(); err != nil {
+		log.Warn().Err(err).Msg("Compute service unavailable")
+	}
+	if err := client.initNetwork(); err != nil {
+		log.Warn().Err(err).Msg("Network service unavailable")
+	}
+
 	return client, nil
 }
 
+// newHTTPTransport builds the HTTP transport from the default one, so proxy
+// settings from the environment keep working, with TLS configured for the
+// CA bundle, optional client certificate and SSL verification setting
+func newHTTPTransport(cfg *config.OpenStackConfig) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate file: %w", err)
+		}
+		// Trust the private CA in addition to the system roots
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+		log.Debug().Str("ca_cert_file", cfg.CACertFile).Msg("Loaded CA certificate bundle")
+	}
+
+	if cfg.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		log.Debug().Str("client_cert_file", cfg.ClientCertFile).Msg("Loaded client certificate")
+	}
+
+	// Disable SSL verification if configured (for development/self-signed certs)
+	if !cfg.VerifySSL {
+		log.Warn().Msg("SSL verification disabled - not recommended for production")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// buildAuthOptions creates the Keystone authentication options for the given method
+func buildAuthOptions(cfg *config.OpenStackConfig, authType string) gophercloud.AuthOptions {
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint: cfg.AuthURL,
+	}
+
+	switch authType {
+	case config.AuthTypeApplicationCredential:
+		// Application credentials carry their own project scope
+		authOpts.ApplicationCredentialID = cfg.ApplicationCredentialID
+		authOpts.ApplicationCredentialSecret = cfg.ApplicationCredentialSecret
+		if cfg.ApplicationCredentialID == "" {
+			authOpts.ApplicationCredentialName = cfg.ApplicationCredentialName
+			setUser(&authOpts, cfg)
+		}
+		return authOpts
+	case config.AuthTypeToken:
+		authOpts.TokenID = cfg.Token
+	case config.AuthTypeTOTP:
+		setUser(&authOpts, cfg)
+		authOpts.Passcode = cfg.Passcode
+	case config.AuthTypeMultiFactor:
+		setUser(&authOpts, cfg)
+		authOpts.Password = cfg.Password
+		authOpts.Passcode = cfg.Passcode
+	default:
+		setUser(&authOpts, cfg)
+		authOpts.Password = cfg.Password
+	}
+
+	// Scope explicitly so the project domain does not replace the user domain
+	if cfg.ProjectID != "" {
+		authOpts.Scope = &gophercloud.AuthScope{ProjectID: cfg.ProjectID}
+	} else if cfg.ProjectName != "" {
+		authOpts.Scope = &gophercloud.AuthScope{ProjectName: cfg.ProjectName}
+		if cfg.ProjectDomainID != "" {
+			authOpts.Scope.DomainID = cfg.ProjectDomainID
+		} else {
+			authOpts.Scope.DomainName = cfg.ProjectDomain
+		}
+	}
+
+	return authOpts
+}
+
+// setUser identifies the user by ID, or by name within the user domain
+func setUser(authOpts *gophercloud.AuthOptions, cfg *config.OpenStackConfig) {
+	if cfg.UserID != "" {
+		authOpts.UserID = cfg.UserID
+		return
+	}
+	authOpts.Username = cfg.Username
+	if cfg.UserDomainID != "" {
+		authOpts.DomainID = cfg.UserDomainID
+		return
+	}
+	authOpts.DomainName = cfg.UserDomain
+}
+
 // initBlockStorage initializes the Block Storage (Cinder) v3 service client
 func (c *Client) initBlockStorage() error {
 	endpointOpts := gophercloud.EndpointOpts{
@@ -108,6 +253,117 @@ func (c *Client) initBlockStorage() error {
 	return nil
 }
 
+// initSharedFileSystems initializes the Shared File Systems (Manila) v2 service client
+func (c *Client) initSharedFileSystems() error {
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       c.config.Region,
+		Availability: gophercloud.Availability(c.config.EndpointType),
+	}
+
+	client, err := openstack.NewSharedFileSystemV2(c.provider, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("creating shared file systems v2 client: %w", err)
+	}
+	client.Microversion = sharedFileSystemsMicroversion
+
+	c.sharedFileSystemsV2 = client
+	log.Debug().Msg("Initialized Shared File Systems v2 client")
+	return nil
+}
+
+// initBaremetal initializes the Bare Metal (Ironic) v1 service client
+func (c *Client) initBaremetal() error {
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       c.config.Region,
+		Availability: gophercloud.Availability(c.config.EndpointType),
+	}
+
+	client, err := openstack.NewBareMetalV1(c.provider, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("creating bare metal v1 client: %w", err)
+	}
+	client.Microversion = baremetalMicroversion
+
+	c.baremetalV1 = client
+	log.Debug().Msg("Initialized Bare Metal v1 client")
+	return nil
+}
+
+// initContainerInfra initializes the Container Infrastructure (Magnum) v1 service client
+func (c *Client) initContainerInfra() error {
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       c.config.Region,
+		Availability: gophercloud.Availability(c.config.EndpointType),
+	}
+
+	client, err := openstack.NewContainerInfraV1(c.provider, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("creating container infra v1 client: %w", err)
+	}
+	client.Microversion = containerInfraMicroversion
+
+	c.containerInfraV1 = client
+	log.Debug().Msg("Initialized Container Infra v1 client")
+	return nil
+}
+
+// initPlacement initializes the Placement v1 service client
+func (c *Client) initPlacement() error {
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       c.config.Region,
+		Availability: gophercloud.Availability(c.config.EndpointType),
+	}
+
+	client, err := openstack.NewPlacementV1(c.provider, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("creating placement v1 client: %w", err)
+	}
+	client.Microversion = placementMicroversion
+
+	c.placementV1 = client
+	log.Debug().Msg("Initialized Placement v1 client")
+	return nil
+}
+
+// initCompute initializes the Compute (Nova) v2 service client
+func (c *Client) initCompute() error {
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       c.config.Region,
+		Availability: gophercloud.Availability(c.config.EndpointType),
+	}
+
+	client, err := openstack.NewComputeV2(c.provider, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("creating compute v2 client: %w", err)
+	}
+
+	c.computeV2 = client
+	log.Debug().Msg("Initialized Compute v2 client")
+	return nil
+}
+
+// initNetwork initializes the Networking (Neutron) v2 service client
+func (c *Client) initNetwork() error {
+	endpointOpts := gophercloud.EndpointOpts{
+		Region:       c.config.Region,
+		Availability: gophercloud.Availability(c.config.EndpointType),
+	}
+
+	client, err := openstack.NewNetworkV2(c.provider, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("creating network v2 client: %w", err)
+	}
+
+	c.networkV2 = client
+	log.Debug().Msg("Initialized Network v2 client")
+	return nil
+}
+
+// ProjectID returns the ID of the project the client is scoped to
+func (c *Client) ProjectID() string {
+	return c.projectID
+}
+
 // Close closes the OpenStack client connections
 func (c *Client) Close() error {
 	// Gophercloud doesn't require explicit connection closing
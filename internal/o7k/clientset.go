@@ -0,0 +1,265 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// CloudInfo describes a configured connection for the clouds_list tool
+type CloudInfo struct {
+	Name        string   `json:"name"`
+	Default     bool     `json:"default"`
+	AuthURL     string   `json:"auth_url"`
+	ProjectName string   `json:"project_name,omitempty"`
+	ProjectID   string   `json:"project_id,omitempty"`
+	Region      string   `json:"region,omitempty"`
+	Connected   []string `json:"connected_regions,omitempty"` // Regions with an authenticated client
+}
+
+// failedClientRetryDelay is how long a failed connection is reported to
+// callers before it is attempted again
+const failedClientRetryDelay = 30 * time.Second
+
+// ClientSet holds one lazily created Client per configured cloud and region
+type ClientSet struct {
+	mu                sync.RWMutex
+	configs           map[string]*config.OpenStackConfig // Fixed once created
+	clients           map[clientKey]*clientEntry
+	perRequest        bool // Callers supply credentials; the configured ones are unused
+	credentialClients map[string]*credentialClient
+}
+
+// clientKey identifies a client by cloud name and region
+type clientKey struct {
+	cloud  string
+	region string
+}
+
+// clientEntry is a client being connected or connected. Callers asking for
+// it meanwhile wait for the one connection attempt instead of starting theirs.
+type clientEntry struct {
+	ready    chan struct{} // Closed once client or err is set
+	client   *Client
+	err      error
+	failedAt time.Time
+}
+
+// connected returns the client once the connection succeeded, without waiting
+func (e *clientEntry) connected() *Client {
+	select {
+	case <-e.ready:
+		return e.client
+	default:
+		return nil
+	}
+}
+
+// retryable reports whether the connection failed long enough ago to be
+// attempted again
+func (e *clientEntry) retryable() bool {
+	select {
+	case <-e.ready:
+		return e.err != nil && time.Since(e.failedAt) > failedClientRetryDelay
+	default:
+		return false
+	}
+}
+
+// clientContextKey stores the client selected for a tool call in its context
+type clientContextKey struct{}
+
+// NewClientSet creates a client set for the default cloud and the named
+// clouds. The default cloud is connected immediately so that configuration
+// errors surface at startup; the others connect on first use. With
+// perRequest, only clients built from caller credentials are handed out.
+func NewClientSet(defaultCfg *config.OpenStackConfig, clouds map[string]config.OpenStackConfig, perRequest bool) (*ClientSet, error) {
+	s := &ClientSet{
+		configs: map[string]*config.OpenStackConfig{
+			config.DefaultCloudName: defaultCfg,
+		},
+		clients:           map[clientKey]*clientEntry{},
+		perRequest:        perRequest,
+		credentialClients: map[string]*credentialClient{},
+	}
+	for name := range clouds {
+		cfg := clouds[name]
+		s.configs[name] = &cfg
+	}
+
+	if !perRequest {
+		if _, err := s.Get(config.DefaultCloudName, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info().Int("clouds", len(s.configs)).Msg("OpenStack client set initialized")
+	return s, nil
+}
+
+// Get returns the client of a cloud and region, creating it on first use.
+// An empty cloud selects the default cloud, an empty region the cloud's
+// configured region. Other regions must be in the service catalog of the
+// cloud. Only the callers of the same cloud and region wait for a connection
+// in progress; a failed one is retried after failedClientRetryDelay.
+func (s *ClientSet) Get(cloud, region string) (*Client, error) {
+	if cloud == "" {
+		cloud = config.DefaultCloudName
+	}
+
+	if s.perRequest {
+		return nil, fmt.Errorf("OpenStack credentials must be supplied with the request")
+	}
+
+	cfg, ok := s.configs[cloud]
+	if !ok {
+		return nil, fmt.Errorf("unknown cloud %q, available: %v", cloud, s.names())
+	}
+	if region == "" {
+		region = cfg.Region
+	}
+
+	key := clientKey{cloud: cloud, region: region}
+	s.mu.RLock()
+	entry, ok := s.clients[key]
+	s.mu.RUnlock()
+	if !ok || entry.retryable() {
+		// Arbitrary region names must not each cost an authentication
+		if region != cfg.Region {
+			base, err := s.Get(cloud, cfg.Region)
+			if err != nil {
+				return nil, err
+			}
+			if err := base.checkRegion(region); err != nil {
+				return nil, fmt.Errorf("cloud %q: %w", cloud, err)
+			}
+		}
+		entry = s.connect(key, cfg)
+	}
+
+	<-entry.ready
+	return entry.client, entry.err
+}
+
+// connect returns the entry of a client, starting its connection unless
+// another caller did first
+func (s *ClientSet) connect(key clientKey, cfg *config.OpenStackConfig) *clientEntry {
+	s.mu.Lock()
+	if entry, ok := s.clients[key]; ok && !entry.retryable() {
+		s.mu.Unlock()
+		return entry
+	}
+	entry := &clientEntry{ready: make(chan struct{})}
+	s.clients[key] = entry
+	s.mu.Unlock()
+
+	regionCfg := *cfg
+	regionCfg.Region = key.region
+
+	log.Info().
+		Str("cloud", key.cloud).
+		Str("region", key.region).
+		Msg("Connecting to OpenStack")
+
+	client, err := NewClient(&regionCfg)
+	if err != nil {
+		entry.err = fmt.Errorf("connecting to cloud %q region %q: %w", key.cloud, key.region, err)
+		entry.failedAt = time.Now()
+	}
+	entry.client = client
+	close(entry.ready)
+	return entry
+}
+
+// Default returns the client of the default cloud in its configured region,
+// or nil when clients are built from request credentials only
+func (s *ClientSet) Default() *Client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cfg := s.configs[config.DefaultCloudName]
+	entry, ok := s.clients[clientKey{cloud: config.DefaultCloudName, region: cfg.Region}]
+	if !ok {
+		return nil
+	}
+	return entry.connected()
+}
+
+// List describes all configured clouds
+func (s *ClientSet) List() []CloudInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]CloudInfo, 0, len(s.configs))
+	for _, name := range s.names() {
+		cfg := s.configs[name]
+		info := CloudInfo{
+			Name:        name,
+			Default:     name == config.DefaultCloudName,
+			AuthURL:     cfg.AuthURL,
+			ProjectName: cfg.ProjectName,
+			ProjectID:   cfg.ProjectID,
+			Region:      cfg.Region,
+		}
+		for key, entry := range s.clients {
+			if key.cloud == name && entry.connected() != nil {
+				info.Connected = append(info.Connected, key.region)
+			}
+		}
+		sort.Strings(info.Connected)
+		result = append(result, info)
+	}
+
+	return result
+}
+
+// Close closes all created clients
+func (s *ClientSet) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for _, entry := range s.clients {
+		client := entry.connected()
+		if client == nil {
+			continue
+		}
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, entry := range s.credentialClients {
+		if err := entry.client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewContext returns a context carrying the client selected for a tool call
+func (s *ClientSet) NewContext(ctx context.Context, client *Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// FromContext returns the client selected for a tool call, or the default client
+func (s *ClientSet) FromContext(ctx context.Context) *Client {
+	if client, ok := ctx.Value(clientContextKey{}).(*Client); ok {
+		return client
+	}
+	return s.Default()
+}
+
+// names returns the sorted cloud names
+func (s *ClientSet) names() []string {
+	names := make([]string, 0, len(s.configs))
+	for name := range s.configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,238 @@
+package o7k
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+)
+
+// fakeKeystone serves token requests with a catalog holding a block storage
+// endpoint in RegionOne, counting them. Requests wait for release when set.
+type fakeKeystone struct {
+	*httptest.Server
+	auths   atomic.Int32
+	status  int
+	release chan struct{}
+	started chan struct{}
+}
+
+// newFakeKeystone starts a fake Keystone answering with status
+func newFakeKeystone(t *testing.T, status int) *fakeKeystone {
+	t.Helper()
+	k := &fakeKeystone{status: status, started: make(chan struct{}, 16)}
+	k.Server = httptest.NewServer(http.HandlerFunc(k.serveHTTP))
+	t.Cleanup(k.Close)
+	return k
+}
+
+func (k *fakeKeystone) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/v3/auth/tokens" {
+		http.NotFound(w, r)
+		return
+	}
+	k.auths.Add(1)
+	k.started <- struct{}{}
+	if k.release != nil {
+		<-k.release
+	}
+	if k.status != http.StatusCreated {
+		w.WriteHeader(k.status)
+		return
+	}
+
+	endpoint := map[string]string{
+		"id":        "e1",
+		"interface": "public",
+		"region":    "RegionOne",
+		"region_id": "RegionOne",
+		"url":       k.URL + "/volume/v3/p1",
+	}
+	body := map[string]any{
+		"token": map[string]any{
+			"expires_at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			"project":    map[string]any{"id": "p1", "name": "demo"},
+			"user":       map[string]any{"id": "u1", "name": "admin"},
+			"catalog": []any{
+				map[string]any{"id": "s1", "type": "block-storage", "name": "cinder", "endpoints": []any{endpoint}},
+				map[string]any{"id": "s2", "type": "volumev3", "name": "cinderv3", "endpoints": []any{endpoint}},
+			},
+		},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Subject-Token", "token")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// cloudConfig returns a password configuration for a fake Keystone
+func cloudConfig(k *fakeKeystone) config.OpenStackConfig {
+	return config.OpenStackConfig{
+		AuthURL:      k.URL + "/v3",
+		Username:     "admin",
+		Password:     "secret",
+		ProjectID:    "p1",
+		UserDomain:   "Default",
+		Region:       "RegionOne",
+		EndpointType: "public",
+		Timeout:      5 * time.Second,
+		VerifySSL:    true,
+	}
+}
+
+// TestClientSetRegionCatalog checks that regions outside the service catalog
+// are rejected without authenticating
+func TestClientSetRegionCatalog(t *testing.T) {
+	keystone := newFakeKeystone(t, http.StatusCreated)
+	cfg := cloudConfig(keystone)
+	clients, err := NewClientSet(&cfg, nil, false)
+	if err != nil {
+		t.Fatalf("creating client set: %v", err)
+	}
+
+	if _, err := clients.Get("", "RegionTwo"); err == nil {
+		t.Error("Get(RegionTwo) succeeded, want unknown region")
+	}
+	if _, err := clients.Get("", "RegionTwo"); err == nil {
+		t.Error("Get(RegionTwo) succeeded, want unknown region")
+	}
+	client, err := clients.Get("", "RegionOne")
+	if err != nil {
+		t.Fatalf("Get(RegionOne): %v", err)
+	}
+	if client != clients.Default() {
+		t.Error("Get(RegionOne) returned a new client, want the default one")
+	}
+	if got := keystone.auths.Load(); got != 1 {
+		t.Errorf("authentications = %d, want 1", got)
+	}
+}
+
+// TestClientSetSlowCloud checks that a cloud still connecting blocks
+// neither other clouds nor the lookups of connected clients
+func TestClientSetSlowCloud(t *testing.T) {
+	fast := newFakeKeystone(t, http.StatusCreated)
+	slow := newFakeKeystone(t, http.StatusCreated)
+	slow.release = make(chan struct{})
+	defer close(slow.release)
+
+	cfg := cloudConfig(fast)
+	clients, err := NewClientSet(&cfg, map[string]config.OpenStackConfig{"slow": cloudConfig(slow)}, false)
+	if err != nil {
+		t.Fatalf("creating client set: %v", err)
+	}
+
+	connecting := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := clients.Get("slow", "")
+			connecting <- err
+		}()
+	}
+	<-slow.started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if clients.Default() == nil {
+			t.Error("Default() = nil while another cloud connects")
+		}
+		if _, err := clients.Get("", ""); err != nil {
+			t.Errorf("Get(default): %v", err)
+		}
+		for _, info := range clients.List() {
+			if info.Name == "slow" && len(info.Connected) > 0 {
+				t.Errorf("slow cloud listed as connected to %v", info.Connected)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("lookups blocked by a connecting cloud")
+	}
+
+	slow.release <- struct{}{}
+	for range 2 {
+		if err := <-connecting; err != nil {
+			t.Errorf("Get(slow): %v", err)
+		}
+	}
+	if got := slow.auths.Load(); got != 1 {
+		t.Errorf("authentications of the slow cloud = %d, want 1", got)
+	}
+}
+
+// TestClientSetFailedCloud checks that a failed connection is reported
+// again without another attempt until the retry delay passes
+func TestClientSetFailedCloud(t *testing.T) {
+	fast := newFakeKeystone(t, http.StatusCreated)
+	down := newFakeKeystone(t, http.StatusUnauthorized)
+
+	cfg := cloudConfig(fast)
+	clients, err := NewClientSet(&cfg, map[string]config.OpenStackConfig{"down": cloudConfig(down)}, false)
+	if err != nil {
+		t.Fatalf("creating client set: %v", err)
+	}
+
+	for range 3 {
+		if _, err := clients.Get("down", ""); err == nil {
+			t.Fatal("Get(down) succeeded, want authentication error")
+		}
+	}
+	if got := down.auths.Load(); got != 1 {
+		t.Errorf("authentications = %d, want 1", got)
+	}
+}
+
+// TestGetWithCredentialsTTL checks that clients built from application
+// credentials, which renew their own token, are authenticated again once
+// their cache entry expires
+func TestGetWithCredentialsTTL(t *testing.T) {
+	keystone := newFakeKeystone(t, http.StatusCreated)
+	cfg := config.OpenStackConfig{
+		AuthURL:      keystone.URL + "/v3",
+		Region:       "RegionOne",
+		EndpointType: "public",
+		Timeout:      5 * time.Second,
+		VerifySSL:    true,
+	}
+	clients, err := NewClientSet(&cfg, nil, true)
+	if err != nil {
+		t.Fatalf("creating client set: %v", err)
+	}
+	creds := RequestCredentials{ApplicationCredentialID: "ac1", ApplicationCredentialSecret: "secret"}
+
+	first, err := clients.GetWithCredentials("", "", creds)
+	if err != nil {
+		t.Fatalf("GetWithCredentials: %v", err)
+	}
+	cached, err := clients.GetWithCredentials("", "", creds)
+	if err != nil {
+		t.Fatalf("GetWithCredentials: %v", err)
+	}
+	if cached != first {
+		t.Error("GetWithCredentials returned a new client within the TTL")
+	}
+
+	entry := clients.credentialClients[creds.cacheKey(config.DefaultCloudName, "RegionOne")]
+	if ttl := time.Until(entry.expiresAt); ttl <= 0 || ttl > credentialClientTTL {
+		t.Errorf("cache entry expires in %s, want within %s", ttl, credentialClientTTL)
+	}
+	entry.expiresAt = time.Now().Add(-time.Second)
+
+	renewed, err := clients.GetWithCredentials("", "", creds)
+	if err != nil {
+		t.Fatalf("GetWithCredentials: %v", err)
+	}
+	if renewed == first {
+		t.Error("GetWithCredentials reused a client past its TTL")
+	}
+	if got := keystone.auths.Load(); got != 2 {
+		t.Errorf("authentications = %d, want 2", got)
+	}
+}
@@ -0,0 +1,118 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/rs/zerolog/log"
+)
+
+// Server represents an OpenStack compute instance with common attributes
+type Server struct {
+	ID               string              `json:"id"`
+	Name             string              `json:"name"`
+	Status           string              `json:"status"`                // ACTIVE, BUILD, SHUTOFF, ERROR, etc.
+	TaskState        string              `json:"task_state,omitempty"`  // set while an operation is in progress
+	PowerState       string              `json:"power_state,omitempty"` // RUNNING, SHUTDOWN, etc.
+	FlavorID         string              `json:"flavor_id,omitempty"`
+	ImageID          string              `json:"image_id,omitempty"`
+	KeyName          string              `json:"key_name,omitempty"`
+	AvailabilityZone string              `json:"availability_zone,omitempty"`
+	Addresses        map[string][]string `json:"addresses"` // Network name to IP addresses
+	VolumeIDs        []string            `json:"volume_ids,omitempty"`
+	Metadata         map[string]string   `json:"metadata"`
+	Fault            string              `json:"fault,omitempty"`
+	CreatedAt        string              `json:"created_at"`
+	UpdatedAt        string              `json:"updated_at"`
+}
+
+// GetServer retrieves a compute instance by ID
+func (c *Client) GetServer(ctx context.Context, serverID string) (*Server, error) {
+	if c.computeV2 == nil {
+		return nil, fmt.Errorf("compute client not initialized")
+	}
+
+	log.Debug().
+		Str("server_id", serverID).
+		Msg("Getting server")
+
+	srv, err := servers.Get(ctx, c.computeV2, serverID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting server %s: %w", serverID, err)
+	}
+
+	return convertServer(srv), nil
+}
+
+// ListServers lists all compute instances of the current project
+func (c *Client) ListServers(ctx context.Context) ([]Server, error) {
+	if c.computeV2 == nil {
+		return nil, fmt.Errorf("compute client not initialized")
+	}
+
+	log.Debug().Msg("Listing servers")
+
+	allPages, err := servers.List(c.computeV2, servers.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing servers: %w", err)
+	}
+
+	allServers, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting servers: %w", err)
+	}
+
+	result := make([]Server, len(allServers))
+	for i, srv := range allServers {
+		result[i] = *convertServer(&srv)
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed servers")
+	return result, nil
+}
+
+// convertServer converts a gophercloud server to our Server type
+func convertServer(srv *servers.Server) *Server {
+	server := &Server{
+		ID:               srv.ID,
+		Name:             srv.Name,
+		Status:           srv.Status,
+		TaskState:        srv.TaskState,
+		KeyName:          srv.KeyName,
+		AvailabilityZone: srv.AvailabilityZone,
+		Addresses:        map[string][]string{},
+		Metadata:         srv.Metadata,
+		Fault:            srv.Fault.Message,
+		CreatedAt:        srv.Created.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:        srv.Updated.Format("2006-01-02T15:04:05Z"),
+	}
+	if srv.PowerState != servers.NOSTATE {
+		server.PowerState = srv.PowerState.String()
+	}
+	if id, ok := srv.Flavor["id"].(string); ok {
+		server.FlavorID = id
+	}
+	// Servers booted from volume have no image
+	if id, ok := srv.Image["id"].(string); ok {
+		server.ImageID = id
+	}
+	for _, volume := range srv.AttachedVolumes {
+		server.VolumeIDs = append(server.VolumeIDs, volume.ID)
+	}
+
+	// Addresses are lists of {"addr": ..., "version": ...} per network
+	for network, value := range srv.Addresses {
+		entries, _ := value.([]any)
+		for _, entry := range entries {
+			if fields, ok := entry.(map[string]any); ok {
+				if addr, ok := fields["addr"].(string); ok {
+					server.Addresses[network] = append(server.Addresses[network], addr)
+				}
+			}
+		}
+		sort.Strings(server.Addresses[network])
+	}
+	return server
+}
@@ -0,0 +1,417 @@
+package o7k
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/containerinfra/v1/certificates"
+	"github.com/gophercloud/gophercloud/v2/openstack/containerinfra/v1/clusters"
+	"github.com/gophercloud/gophercloud/v2/openstack/containerinfra/v1/clustertemplates"
+	"github.com/gophercloud/gophercloud/v2/openstack/containerinfra/v1/nodegroups"
+	"github.com/rs/zerolog/log"
+)
+
+// ClusterTemplate represents a Magnum cluster template
+type ClusterTemplate struct {
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	COE               string            `json:"coe"` // kubernetes, swarm, etc.
+	ImageID           string            `json:"image_id"`
+	FlavorID          string            `json:"flavor_id"`
+	MasterFlavorID    string            `json:"master_flavor_id"`
+	ExternalNetworkID string            `json:"external_network_id"`
+	NetworkDriver     string            `json:"network_driver"`
+	VolumeDriver      string            `json:"volume_driver"`
+	DockerVolumeSize  int               `json:"docker_volume_size"`
+	MasterLBEnabled   bool              `json:"master_lb_enabled"`
+	FloatingIPEnabled bool              `json:"floating_ip_enabled"`
+	TLSDisabled       bool              `json:"tls_disabled"`
+	Public            bool              `json:"public"`
+	Hidden            bool              `json:"hidden"`
+	Labels            map[string]string `json:"labels"`
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
+}
+
+// Cluster represents a Magnum container orchestration cluster
+type Cluster struct {
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	ClusterTemplateID  string            `json:"cluster_template_id"`
+	Status             string            `json:"status"` // CREATE_COMPLETE, UPDATE_IN_PROGRESS, etc.
+	StatusReason       string            `json:"status_reason,omitempty"`
+	HealthStatus       string            `json:"health_status,omitempty"` // HEALTHY, UNHEALTHY, UNKNOWN
+	HealthStatusReason map[string]any    `json:"health_status_reason,omitempty"`
+	APIAddress         string            `json:"api_address"`
+	COEVersion         string            `json:"coe_version"`
+	MasterCount        int               `json:"master_count"`
+	NodeCount          int               `json:"node_count"`
+	MasterAddresses    []string          `json:"master_addresses"`
+	NodeAddresses      []string          `json:"node_addresses"`
+	FlavorID           string            `json:"flavor_id"`
+	MasterFlavorID     string            `json:"master_flavor_id"`
+	KeyPair            string            `json:"keypair"`
+	StackID            string            `json:"stack_id"`
+	Faults             map[string]string `json:"faults,omitempty"`
+	Labels             map[string]string `json:"labels"`
+	CreatedAt          string            `json:"created_at"`
+	UpdatedAt          string            `json:"updated_at"`
+}
+
+// NodeGroup represents a group of identically configured nodes in a cluster
+type NodeGroup struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Role         string `json:"role"` // master, worker, or a custom role
+	IsDefault    bool   `json:"is_default"`
+	NodeCount    int    `json:"node_count"`
+	MinNodeCount int    `json:"min_node_count"`
+	MaxNodeCount *int   `json:"max_node_count,omitempty"`
+	FlavorID     string `json:"flavor_id"`
+	ImageID      string `json:"image_id"`
+	Status       string `json:"status"`
+}
+
+// ClusterHealth summarizes the health and size of a cluster
+type ClusterHealth struct {
+	ClusterID          string         `json:"cluster_id"`
+	Name               string         `json:"name"`
+	Status             string         `json:"status"`
+	StatusReason       string         `json:"status_reason,omitempty"`
+	HealthStatus       string         `json:"health_status"`
+	HealthStatusReason map[string]any `json:"health_status_reason,omitempty"`
+	MasterCount        int            `json:"master_count"`
+	NodeCount          int            `json:"node_count"`
+	NodeGroups         []NodeGroup    `json:"node_groups"`
+}
+
+// ClusterCA contains the material needed to trust a cluster's API server
+type ClusterCA struct {
+	ClusterID  string `json:"cluster_id"`
+	APIAddress string `json:"api_address"`
+	CACert     string `json:"ca_cert"` // PEM encoded
+}
+
+// ClusterCredentials contains a kubeconfig with a freshly signed client certificate
+type ClusterCredentials struct {
+	ClusterID  string `json:"cluster_id"`
+	APIAddress string `json:"api_address"`
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+// ListClusterTemplates lists all cluster templates visible to the current project
+func (c *Client) ListClusterTemplates(ctx context.Context) ([]ClusterTemplate, error) {
+	if c.containerInfraV1 == nil {
+		return nil, fmt.Errorf("container infra client not initialized")
+	}
+
+	log.Debug().Msg("Listing cluster templates")
+
+	allPages, err := clustertemplates.List(c.containerInfraV1, clustertemplates.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster templates: %w", err)
+	}
+
+	allTemplates, err := clustertemplates.ExtractClusterTemplates(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting cluster templates: %w", err)
+	}
+
+	result := make([]ClusterTemplate, len(allTemplates))
+	for i, tmpl := range allTemplates {
+		result[i] = *convertClusterTemplate(&tmpl)
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed cluster templates")
+	return result, nil
+}
+
+// GetClusterTemplate retrieves a cluster template by ID or name
+func (c *Client) GetClusterTemplate(ctx context.Context, templateID string) (*ClusterTemplate, error) {
+	if c.containerInfraV1 == nil {
+		return nil, fmt.Errorf("container infra client not initialized")
+	}
+
+	log.Debug().
+		Str("cluster_template_id", templateID).
+		Msg("Getting cluster template")
+
+	tmpl, err := clustertemplates.Get(ctx, c.containerInfraV1, templateID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster template %s: %w", templateID, err)
+	}
+
+	return convertClusterTemplate(tmpl), nil
+}
+
+// ListClusters lists all clusters in the current project
+func (c *Client) ListClusters(ctx context.Context) ([]Cluster, error) {
+	if c.containerInfraV1 == nil {
+		return nil, fmt.Errorf("container infra client not initialized")
+	}
+
+	log.Debug().Msg("Listing clusters")
+
+	allPages, err := clusters.ListDetail(c.containerInfraV1, clusters.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing clusters: %w", err)
+	}
+
+	allClusters, err := clusters.ExtractClusters(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting clusters: %w", err)
+	}
+
+	result := make([]Cluster, len(allClusters))
+	for i, cluster := range allClusters {
+		result[i] = *convertCluster(&cluster)
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed clusters")
+	return result, nil
+}
+
+// GetCluster retrieves a cluster by ID or name
+func (c *Client) GetCluster(ctx context.Context, clusterID string) (*Cluster, error) {
+	if c.containerInfraV1 == nil {
+		return nil, fmt.Errorf("container infra client not initialized")
+	}
+
+	log.Debug().
+		Str("cluster_id", clusterID).
+		Msg("Getting cluster")
+
+	cluster, err := clusters.Get(ctx, c.containerInfraV1, clusterID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %w", clusterID, err)
+	}
+
+	return convertCluster(cluster), nil
+}
+
+// ListNodeGroups lists the node groups of a cluster
+func (c *Client) ListNodeGroups(ctx context.Context, clusterID string) ([]NodeGroup, error) {
+	if c.containerInfraV1 == nil {
+		return nil, fmt.Errorf("container infra client not initialized")
+	}
+
+	log.Debug().
+		Str("cluster_id", clusterID).
+		Msg("Listing node groups")
+
+	allPages, err := nodegroups.List(c.containerInfraV1, clusterID, nodegroups.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing node groups of cluster %s: %w", clusterID, err)
+	}
+
+	allGroups, err := nodegroups.ExtractNodeGroups(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting node groups: %w", err)
+	}
+
+	result := make([]NodeGroup, len(allGroups))
+	for i, ng := range allGroups {
+		result[i] = *convertNodeGroup(&ng)
+	}
+
+	return result, nil
+}
+
+// GetClusterHealth summarizes a cluster's status, health and node counts per node group
+func (c *Client) GetClusterHealth(ctx context.Context, clusterID string) (*ClusterHealth, error) {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := c.ListNodeGroups(ctx, cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterHealth{
+		ClusterID:          cluster.ID,
+		Name:               cluster.Name,
+		Status:             cluster.Status,
+		StatusReason:       cluster.StatusReason,
+		HealthStatus:       cluster.HealthStatus,
+		HealthStatusReason: cluster.HealthStatusReason,
+		MasterCount:        cluster.MasterCount,
+		NodeCount:          cluster.NodeCount,
+		NodeGroups:         groups,
+	}, nil
+}
+
+// ResizeNodeGroup changes the node count of a cluster node group
+func (c *Client) ResizeNodeGroup(ctx context.Context, clusterID, nodeGroup string, nodeCount int) error {
+	if c.containerInfraV1 == nil {
+		return fmt.Errorf("container infra client not initialized")
+	}
+
+	log.Info().
+		Str("cluster_id", clusterID).
+		Str("node_group", nodeGroup).
+		Int("node_count", nodeCount).
+		Msg("Resizing node group")
+
+	resizeOpts := clusters.ResizeOpts{
+		NodeCount: &nodeCount,
+		NodeGroup: nodeGroup,
+	}
+
+	if _, err := clusters.Resize(ctx, c.containerInfraV1, clusterID, resizeOpts).Extract(); err != nil {
+		return fmt.Errorf("resizing node group %s of cluster %s: %w", nodeGroup, clusterID, err)
+	}
+
+	return nil
+}
+
+// GetClusterCA retrieves the CA certificate and API address of a cluster
+func (c *Client) GetClusterCA(ctx context.Context, clusterID string) (*ClusterCA, error) {
+	cluster, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := certificates.Get(ctx, c.containerInfraV1, cluster.ID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting CA certificate of cluster %s: %w", clusterID, err)
+	}
+
+	return &ClusterCA{
+		ClusterID:  cluster.ID,
+		APIAddress: cluster.APIAddress,
+		CACert:     ca.PEM,
+	}, nil
+}
+
+// CreateClusterCredentials signs a new admin client certificate for a
+// Kubernetes cluster and returns a ready to use kubeconfig
+func (c *Client) CreateClusterCredentials(ctx context.Context, clusterID string) (*ClusterCredentials, error) {
+	ca, err := c.GetClusterCA(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("cluster_id", ca.ClusterID).
+		Msg("Signing cluster client certificate")
+
+	// Same subject as `openstack coe cluster config` so RBAC behaves identically
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("generating client key: %w", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "admin", Organization: []string{"system:masters"}},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate request: %w", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	cert, err := certificates.Create(ctx, c.containerInfraV1, certificates.CreateOpts{
+		ClusterUUID: ca.ClusterID,
+		CSR:         string(csrPEM),
+	}).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("signing client certificate for cluster %s: %w", clusterID, err)
+	}
+
+	return &ClusterCredentials{
+		ClusterID:  ca.ClusterID,
+		APIAddress: ca.APIAddress,
+		Kubeconfig: buildKubeconfig(ca.ClusterID, ca.APIAddress, ca.CACert, cert.PEM, string(keyPEM)),
+	}, nil
+}
+
+// buildKubeconfig renders a single-context kubeconfig
+func buildKubeconfig(name, server, caPEM, certPEM, keyPEM string) string {
+	enc := base64.StdEncoding.EncodeToString
+
+	var b strings.Builder
+	b.WriteString("apiVersion: v1\nkind: Config\n")
+	b.WriteString("clusters:\n")
+	fmt.Fprintf(&b, "- name: %s\n  cluster:\n    server: %s\n    certificate-authority-data: %s\n", name, server, enc([]byte(caPEM)))
+	b.WriteString("users:\n")
+	fmt.Fprintf(&b, "- name: admin\n  user:\n    client-certificate-data: %s\n    client-key-data: %s\n", enc([]byte(certPEM)), enc([]byte(keyPEM)))
+	b.WriteString("contexts:\n")
+	fmt.Fprintf(&b, "- name: default\n  context:\n    cluster: %s\n    user: admin\n", name)
+	b.WriteString("current-context: default\n")
+	return b.String()
+}
+
+// convertClusterTemplate converts a Gophercloud cluster template to our ClusterTemplate type
+func convertClusterTemplate(tmpl *clustertemplates.ClusterTemplate) *ClusterTemplate {
+	return &ClusterTemplate{
+		ID:                tmpl.UUID,
+		Name:              tmpl.Name,
+		COE:               tmpl.COE,
+		ImageID:           tmpl.ImageID,
+		FlavorID:          tmpl.FlavorID,
+		MasterFlavorID:    tmpl.MasterFlavorID,
+		ExternalNetworkID: tmpl.ExternalNetworkID,
+		NetworkDriver:     tmpl.NetworkDriver,
+		VolumeDriver:      tmpl.VolumeDriver,
+		DockerVolumeSize:  tmpl.DockerVolumeSize,
+		MasterLBEnabled:   tmpl.MasterLBEnabled,
+		FloatingIPEnabled: tmpl.FloatingIPEnabled,
+		TLSDisabled:       tmpl.TLSDisabled,
+		Public:            tmpl.Public,
+		Hidden:            tmpl.Hidden,
+		Labels:            tmpl.Labels,
+		CreatedAt:         tmpl.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:         tmpl.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// convertCluster converts a Gophercloud cluster to our Cluster type
+func convertCluster(cluster *clusters.Cluster) *Cluster {
+	return &Cluster{
+		ID:                 cluster.UUID,
+		Name:               cluster.Name,
+		ClusterTemplateID:  cluster.ClusterTemplateID,
+		Status:             cluster.Status,
+		StatusReason:       cluster.StatusReason,
+		HealthStatus:       cluster.HealthStatus,
+		HealthStatusReason: cluster.HealthStatusReason,
+		APIAddress:         cluster.APIAddress,
+		COEVersion:         cluster.COEVersion,
+		MasterCount:        cluster.MasterCount,
+		NodeCount:          cluster.NodeCount,
+		MasterAddresses:    cluster.MasterAddresses,
+		NodeAddresses:      cluster.NodeAddresses,
+		FlavorID:           cluster.FlavorID,
+		MasterFlavorID:     cluster.MasterFlavorID,
+		KeyPair:            cluster.KeyPair,
+		StackID:            cluster.StackID,
+		Faults:             cluster.Faults,
+		Labels:             cluster.Labels,
+		CreatedAt:          cluster.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:          cluster.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// convertNodeGroup converts a Gophercloud node group to our NodeGroup type
+func convertNodeGroup(ng *nodegroups.NodeGroup) *NodeGroup {
+	return &NodeGroup{
+		ID:           ng.UUID,
+		Name:         ng.Name,
+		Role:         ng.Role,
+		IsDefault:    ng.IsDefault,
+		NodeCount:    ng.NodeCount,
+		MinNodeCount: ng.MinNodeCount,
+		MaxNodeCount: ng.MaxNodeCount,
+		FlavorID:     ng.FlavorID,
+		ImageID:      ng.ImageID,
+		Status:       ng.Status,
+	}
+}
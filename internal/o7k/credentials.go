@@ -0,0 +1,203 @@
+package o7k
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// maxCredentialClients bounds the number of cached per-caller clients
+const maxCredentialClients = 256
+
+// credentialClientTTL bounds how long a client built from request
+// credentials is reused. Clients able to renew their token would otherwise
+// keep working after the credential is revoked or rotated.
+const credentialClientTTL = 5 * time.Minute
+
+// RequestCredentials are OpenStack credentials supplied by an MCP client with
+// its HTTP requests, used instead of the configured service account
+type RequestCredentials struct {
+	Token                       string
+	ApplicationCredentialID     string
+	ApplicationCredentialSecret string
+}
+
+// credentialsContextKey stores the RequestCredentials of a call in its context
+type credentialsContextKey struct{}
+
+// credentialClient is a cached client built from request credentials
+type credentialClient struct {
+	client    *Client
+	expiresAt time.Time // End of the TTL, or of the token when earlier
+	lastUsed  time.Time
+}
+
+// IsZero reports whether no credentials were supplied
+func (c RequestCredentials) IsZero() bool {
+	return c.Token == "" && c.ApplicationCredentialID == ""
+}
+
+// Validate checks that the credentials form exactly one complete method
+func (c RequestCredentials) Validate() error {
+	switch {
+	case c.Token != "" && c.ApplicationCredentialID != "":
+		return fmt.Errorf("send either a token or an application credential, not both")
+	case c.ApplicationCredentialID != "" && c.ApplicationCredentialSecret == "":
+		return fmt.Errorf("application credential secret is missing")
+	case c.IsZero():
+		return fmt.Errorf("no credentials supplied")
+	}
+	return nil
+}
+
+// cacheKey hashes the credentials with the target cloud and region so that
+// secrets are never kept as map keys
+func (c RequestCredentials) cacheKey(cloud, region string) string {
+	h := sha256.New()
+	for _, part := range []string{cloud, region, c.Token, c.ApplicationCredentialID, c.ApplicationCredentialSecret} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Key identifies the credentials without exposing them
+func (c RequestCredentials) Key() string {
+	return c.cacheKey("", "")
+}
+
+// WithRequestCredentials returns a context carrying the caller's credentials
+func WithRequestCredentials(ctx context.Context, creds RequestCredentials) context.Context {
+	return context.WithValue(ctx, credentialsContextKey{}, creds)
+}
+
+// RequestCredentialsFromContext returns the caller's credentials, if any
+func RequestCredentialsFromContext(ctx context.Context) (RequestCredentials, bool) {
+	creds, ok := ctx.Value(credentialsContextKey{}).(RequestCredentials)
+	return creds, ok && !creds.IsZero()
+}
+
+// PerRequestCredentials reports whether callers must supply their own credentials
+func (s *ClientSet) PerRequestCredentials() bool {
+	return s.perRequest
+}
+
+// GetWithCredentials returns a client of a cloud and region authenticated
+// with the caller's credentials. Clients are cached by credentials for
+// credentialClientTTL, or until their token expires when earlier; the
+// credentials are then authenticated again.
+func (s *ClientSet) GetWithCredentials(cloud, region string, creds RequestCredentials) (*Client, error) {
+	if err := creds.Validate(); err != nil {
+		return nil, err
+	}
+	if cloud == "" {
+		cloud = config.DefaultCloudName
+	}
+
+	cfg, ok := s.configs[cloud]
+	if !ok {
+		return nil, fmt.Errorf("unknown cloud %q, available: %v", cloud, s.names())
+	}
+	if region == "" {
+		region = cfg.Region
+	}
+	key := creds.cacheKey(cloud, region)
+	s.mu.Lock()
+	if cached, ok := s.credentialClients[key]; ok && !cached.expired() {
+		cached.lastUsed = time.Now()
+		s.mu.Unlock()
+		return cached.client, nil
+	}
+	s.mu.Unlock()
+
+	// Arbitrary region names must not each cost an authentication
+	if region != cfg.Region {
+		base, err := s.GetWithCredentials(cloud, cfg.Region, creds)
+		if err != nil {
+			return nil, err
+		}
+		if err := base.checkRegion(region); err != nil {
+			return nil, fmt.Errorf("cloud %q: %w", cloud, err)
+		}
+	}
+
+	// Keep the connection settings of the cloud, replace its credentials.
+	// The caller's token or application credential carries its own scope.
+	userCfg := config.OpenStackConfig{
+		AuthURL:                     cfg.AuthURL,
+		Token:                       creds.Token,
+		ApplicationCredentialID:     creds.ApplicationCredentialID,
+		ApplicationCredentialSecret: creds.ApplicationCredentialSecret,
+		Region:                      region,
+		EndpointType:                cfg.EndpointType,
+		Timeout:                     cfg.Timeout,
+		MaxRetries:                  cfg.MaxRetries,
+		VerifySSL:                   cfg.VerifySSL,
+		CACertFile:                  cfg.CACertFile,
+		ClientCertFile:              cfg.ClientCertFile,
+		ClientKeyFile:               cfg.ClientKeyFile,
+	}
+
+	client, err := NewClient(&userCfg)
+	if err != nil {
+		return nil, fmt.Errorf("authenticating with request credentials: %w", err)
+	}
+
+	now := time.Now()
+	entry := &credentialClient{
+		client:    client,
+		expiresAt: now.Add(credentialClientTTL),
+		lastUsed:  now,
+	}
+	if info, err := client.TokenInfo(); err == nil && info.ExpiresAt.Before(entry.expiresAt) {
+		entry.expiresAt = info.ExpiresAt
+	}
+
+	s.mu.Lock()
+	s.credentialClients[key] = entry
+	s.pruneCredentialClients()
+	s.mu.Unlock()
+
+	log.Debug().
+		Str("cloud", cloud).
+		Str("region", region).
+		Str("project_id", client.ProjectID()).
+		Msg("Created client from request credentials")
+
+	return client, nil
+}
+
+// expired reports whether the cached client must no longer be used
+func (c *credentialClient) expired() bool {
+	return time.Now().After(c.expiresAt)
+}
+
+// pruneCredentialClients drops expired clients and, above the cache limit,
+// the least recently used ones; the caller holds the lock
+func (s *ClientSet) pruneCredentialClients() {
+	for key, entry := range s.credentialClients {
+		if entry.expired() {
+			delete(s.credentialClients, key)
+		}
+	}
+	if len(s.credentialClients) <= maxCredentialClients {
+		return
+	}
+
+	keys := make([]string, 0, len(s.credentialClients))
+	for key := range s.credentialClients {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		return s.credentialClients[keys[a]].lastUsed.Before(s.credentialClients[keys[b]].lastUsed)
+	})
+	for _, key := range keys[:len(keys)-maxCredentialClients] {
+		delete(s.credentialClients, key)
+	}
+}
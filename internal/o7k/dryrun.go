@@ -0,0 +1,99 @@
+package o7k
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrDryRun is returned for the mutating requests of a dry run instead of
+// sending them
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// DryRunRequest is an OpenStack API request captured in a dry run
+type DryRunRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Body   interface{} `json:"body,omitempty"` // Decoded JSON, or the raw body
+}
+
+// DryRun collects the mutating requests of one tool call instead of
+// sending them. Reads are still sent, so referenced resources resolve.
+type DryRun struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// dryRunContextKey stores the DryRun collector in a context
+type dryRunContextKey struct{}
+
+// WithDryRun returns a context whose mutating OpenStack API calls are
+// captured and fail with ErrDryRun
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	dryRun := &DryRun{}
+	return context.WithValue(ctx, dryRunContextKey{}, dryRun), dryRun
+}
+
+// Requests returns the captured requests in call order
+func (d *DryRun) Requests() []DryRunRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]DryRunRequest(nil), d.requests...)
+}
+
+// add records a captured request
+func (d *DryRun) add(request DryRunRequest) {
+	d.mu.Lock()
+	d.requests = append(d.requests, request)
+	d.mu.Unlock()
+}
+
+// dryRunTransport captures the mutating requests made with a dry-run context
+type dryRunTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends reads and token requests, and captures everything else
+// when the request's context is a dry run
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dryRun, ok := req.Context().Value(dryRunContextKey{}).(*DryRun)
+	if !ok || isReadRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	captured := DryRunRequest{Method: req.Method, URL: req.URL.String()}
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			var body interface{}
+			if err := json.Unmarshal(data, &body); err != nil {
+				body = string(bytes.TrimSpace(data))
+			}
+			captured.Body = body
+		}
+	}
+	dryRun.add(captured)
+	return nil, ErrDryRun
+}
+
+// isReadRequest reports whether a request has no side effects. Requests
+// issuing a token are let through, so that a tool whose token expires can
+// still re-authenticate; Client.Reauthenticate captures its own request,
+// so a dry run of auth_reauthenticate issues no token. Revoking a token is
+// captured like any other write.
+func isReadRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/auth/tokens")
+}
@@ -0,0 +1,30 @@
+package o7k
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestIsReadRequest checks which requests a dry run still sends
+func TestIsReadRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   bool
+	}{
+		{http.MethodGet, "https://cinder.example.com/v3/p/volumes", true},
+		{http.MethodHead, "https://keystone.example.com/v3/auth/tokens", true},
+		{http.MethodPost, "https://keystone.example.com/v3/auth/tokens", true},
+		{http.MethodDelete, "https://keystone.example.com/v3/auth/tokens", false},
+		{http.MethodPost, "https://cinder.example.com/v3/p/volumes", false},
+		{http.MethodDelete, "https://cinder.example.com/v3/p/volumes/1", false},
+		{http.MethodPatch, "https://ironic.example.com/v1/nodes/1", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.url, nil)
+		if got := isReadRequest(req); got != tt.want {
+			t.Errorf("isReadRequest(%s %s) = %t, want %t", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
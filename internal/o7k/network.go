@@ -0,0 +1,52 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/networks"
+	"github.com/rs/zerolog/log"
+)
+
+// Network represents a Neutron network
+type Network struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Status  string   `json:"status"` // ACTIVE, DOWN, BUILD or ERROR
+	Shared  bool     `json:"shared"`
+	Subnets []string `json:"subnets"`
+}
+
+// ListNetworks lists the networks visible to the current project, including
+// shared and external ones
+func (c *Client) ListNetworks(ctx context.Context) ([]Network, error) {
+	if c.networkV2 == nil {
+		return nil, fmt.Errorf("network client not initialized")
+	}
+
+	log.Debug().Msg("Listing networks")
+
+	allPages, err := networks.List(c.networkV2, networks.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing networks: %w", err)
+	}
+
+	allNetworks, err := networks.ExtractNetworks(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting networks: %w", err)
+	}
+
+	result := make([]Network, len(allNetworks))
+	for i, network := range allNetworks {
+		result[i] = Network{
+			ID:      network.ID,
+			Name:    network.Name,
+			Status:  network.Status,
+			Shared:  network.Shared,
+			Subnets: network.Subnets,
+		}
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed networks")
+	return result, nil
+}
@@ -0,0 +1,218 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/placement/v1/resourceproviders"
+	"github.com/rs/zerolog/log"
+)
+
+// ResourceProvider represents a Placement resource provider (compute node, shared storage pool, etc.)
+type ResourceProvider struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Generation       int    `json:"generation"`
+	ParentProviderID string `json:"parent_provider_id,omitempty"`
+	RootProviderID   string `json:"root_provider_id,omitempty"`
+}
+
+// ResourceClassInventory combines inventory and usage for one resource class of a provider
+type ResourceClassInventory struct {
+	ResourceClass   string  `json:"resource_class"` // VCPU, MEMORY_MB, DISK_GB, etc.
+	Total           int     `json:"total"`
+	Reserved        int     `json:"reserved"`
+	AllocationRatio float32 `json:"allocation_ratio"`
+	MinUnit         int     `json:"min_unit"`
+	MaxUnit         int     `json:"max_unit"`
+	StepSize        int     `json:"step_size"`
+	Capacity        int     `json:"capacity"` // (total - reserved) * allocation_ratio
+	Used            int     `json:"used"`
+	Free            int     `json:"free"` // capacity - used
+}
+
+// ResourceProviderInventory is the inventory and usage of a resource provider
+type ResourceProviderInventory struct {
+	ProviderID string                   `json:"provider_id"`
+	Generation int                      `json:"generation"`
+	Resources  []ResourceClassInventory `json:"resources"`
+}
+
+// ConsumerAllocation is the set of resources a consumer holds on one provider
+type ConsumerAllocation struct {
+	ProviderID string         `json:"provider_id"`
+	Resources  map[string]int `json:"resources"`
+}
+
+// ConsumerAllocations lists the allocations held by a consumer (usually a server UUID)
+type ConsumerAllocations struct {
+	ConsumerID  string               `json:"consumer_id"`
+	ProjectID   string               `json:"project_id,omitempty"`
+	UserID      string               `json:"user_id,omitempty"`
+	Allocations []ConsumerAllocation `json:"allocations"`
+}
+
+// ListResourceProvidersOpts contains filters for listing resource providers
+type ListResourceProvidersOpts struct {
+	Name      string `json:"name,omitempty"`
+	InTree    string `json:"in_tree,omitempty"`   // Provider UUID whose tree to list
+	Resources string `json:"resources,omitempty"` // e.g. "VCPU:4,MEMORY_MB:8192"
+	Required  string `json:"required,omitempty"`  // Comma separated traits, "!" prefix forbids
+}
+
+// ListResourceProviders lists resource providers matching the given filters
+func (c *Client) ListResourceProviders(ctx context.Context, opts ListResourceProvidersOpts) ([]ResourceProvider, error) {
+	if c.placementV1 == nil {
+		return nil, fmt.Errorf("placement client not initialized")
+	}
+
+	log.Debug().
+		Str("name", opts.Name).
+		Str("resources", opts.Resources).
+		Msg("Listing resource providers")
+
+	listOpts := resourceproviders.ListOpts{
+		Name:      opts.Name,
+		InTree:    opts.InTree,
+		Resources: opts.Resources,
+		Required:  opts.Required,
+	}
+
+	allPages, err := resourceproviders.List(c.placementV1, listOpts).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing resource providers: %w", err)
+	}
+
+	allProviders, err := resourceproviders.ExtractResourceProviders(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting resource providers: %w", err)
+	}
+
+	result := make([]ResourceProvider, len(allProviders))
+	for i, rp := range allProviders {
+		result[i] = ResourceProvider{
+			ID:               rp.UUID,
+			Name:             rp.Name,
+			Generation:       rp.Generation,
+			ParentProviderID: rp.ParentProviderUUID,
+			RootProviderID:   rp.RootProviderUUID,
+		}
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed resource providers")
+	return result, nil
+}
+
+// GetResourceProviderInventory returns the inventories of a provider joined with their current usage
+func (c *Client) GetResourceProviderInventory(ctx context.Context, providerID string) (*ResourceProviderInventory, error) {
+	if c.placementV1 == nil {
+		return nil, fmt.Errorf("placement client not initialized")
+	}
+
+	log.Debug().
+		Str("provider_id", providerID).
+		Msg("Getting resource provider inventory")
+
+	inv, err := resourceproviders.GetInventories(ctx, c.placementV1, providerID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting inventories of resource provider %s: %w", providerID, err)
+	}
+
+	usage, err := resourceproviders.GetUsages(ctx, c.placementV1, providerID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting usages of resource provider %s: %w", providerID, err)
+	}
+
+	result := &ResourceProviderInventory{
+		ProviderID: providerID,
+		Generation: inv.ResourceProviderGeneration,
+		Resources:  make([]ResourceClassInventory, 0, len(inv.Inventories)),
+	}
+	for class, i := range inv.Inventories {
+		capacity := int(float32(i.Total-i.Reserved) * i.AllocationRatio)
+		used := usage.Usages[class]
+		result.Resources = append(result.Resources, ResourceClassInventory{
+			ResourceClass:   class,
+			Total:           i.Total,
+			Reserved:        i.Reserved,
+			AllocationRatio: i.AllocationRatio,
+			MinUnit:         i.MinUnit,
+			MaxUnit:         i.MaxUnit,
+			StepSize:        i.StepSize,
+			Capacity:        capacity,
+			Used:            used,
+			Free:            capacity - used,
+		})
+	}
+	sort.Slice(result.Resources, func(a, b int) bool {
+		return result.Resources[a].ResourceClass < result.Resources[b].ResourceClass
+	})
+
+	return result, nil
+}
+
+// GetResourceProviderTraits lists the traits of a resource provider
+func (c *Client) GetResourceProviderTraits(ctx context.Context, providerID string) ([]string, error) {
+	if c.placementV1 == nil {
+		return nil, fmt.Errorf("placement client not initialized")
+	}
+
+	log.Debug().
+		Str("provider_id", providerID).
+		Msg("Getting resource provider traits")
+
+	traits, err := resourceproviders.GetTraits(ctx, c.placementV1, providerID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting traits of resource provider %s: %w", providerID, err)
+	}
+
+	sort.Strings(traits.Traits)
+	return traits.Traits, nil
+}
+
+// GetConsumerAllocations lists the allocations held by a consumer across all providers
+func (c *Client) GetConsumerAllocations(ctx context.Context, consumerID string) (*ConsumerAllocations, error) {
+	if c.placementV1 == nil {
+		return nil, fmt.Errorf("placement client not initialized")
+	}
+
+	log.Debug().
+		Str("consumer_id", consumerID).
+		Msg("Getting consumer allocations")
+
+	// Gophercloud only exposes allocations per provider, so query the
+	// consumer endpoint directly
+	var body struct {
+		Allocations map[string]struct {
+			Resources map[string]int `json:"resources"`
+		} `json:"allocations"`
+		ProjectID string `json:"project_id"`
+		UserID    string `json:"user_id"`
+	}
+	_, err := c.placementV1.Get(ctx, c.placementV1.ServiceURL("allocations", consumerID), &body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting allocations of consumer %s: %w", consumerID, err)
+	}
+
+	result := &ConsumerAllocations{
+		ConsumerID:  consumerID,
+		ProjectID:   body.ProjectID,
+		UserID:      body.UserID,
+		Allocations: make([]ConsumerAllocation, 0, len(body.Allocations)),
+	}
+	for providerID, alloc := range body.Allocations {
+		result.Allocations = append(result.Allocations, ConsumerAllocation{
+			ProviderID: providerID,
+			Resources:  alloc.Resources,
+		})
+	}
+	sort.Slice(result.Allocations, func(a, b int) bool {
+		return result.Allocations[a].ProviderID < result.Allocations[b].ProviderID
+	})
+
+	return result, nil
+}
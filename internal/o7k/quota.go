@@ -0,0 +1,323 @@
+package o7k
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	blockquotas "github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/quotasets"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/limits"
+	computequotas "github.com/gophercloud/gophercloud/v2/openstack/compute/v2/quotasets"
+	networkquotas "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/quotas"
+	"github.com/rs/zerolog/log"
+)
+
+// Quota service names used in QuotaUsage.Service
+const (
+	QuotaServiceCompute      = "compute"
+	QuotaServiceBlockStorage = "block_storage"
+	QuotaServiceNetwork      = "network"
+)
+
+// QuotaUsage is the normalized quota usage of one resource of one service.
+// A Limit of -1 means unlimited, in which case Remaining is also -1.
+type QuotaUsage struct {
+	Service   string `json:"service"`
+	Resource  string `json:"resource"`
+	Limit     int    `json:"limit"`
+	InUse     int    `json:"in_use"`
+	Reserved  int    `json:"reserved"`
+	Remaining int    `json:"remaining"`
+}
+
+// ProjectQuotaUsage gathers the quota usage of a project across services
+type ProjectQuotaUsage struct {
+	ProjectID string            `json:"project_id"`
+	Usage     []QuotaUsage      `json:"usage"`
+	Errors    map[string]string `json:"errors,omitempty"` // Services that could not be queried
+}
+
+// QuotaSet is the quota limits of a project in one service, as returned by
+// an update. A limit of -1 means unlimited.
+type QuotaSet struct {
+	ProjectID string         `json:"project_id"`
+	Service   string         `json:"service"`
+	Limits    map[string]int `json:"limits"`
+}
+
+// UpdateComputeQuotaOpts contains the Nova quotas to change; nil fields are left untouched
+type UpdateComputeQuotaOpts struct {
+	Instances          *int `json:"instances,omitempty"`
+	Cores              *int `json:"cores,omitempty"`
+	RAM                *int `json:"ram,omitempty"` // MB
+	KeyPairs           *int `json:"key_pairs,omitempty"`
+	ServerGroups       *int `json:"server_groups,omitempty"`
+	ServerGroupMembers *int `json:"server_group_members,omitempty"`
+	MetadataItems      *int `json:"metadata_items,omitempty"`
+}
+
+// UpdateBlockStorageQuotaOpts contains the Cinder quotas to change; nil fields are left untouched
+type UpdateBlockStorageQuotaOpts struct {
+	Volumes            *int `json:"volumes,omitempty"`
+	Snapshots          *int `json:"snapshots,omitempty"`
+	Gigabytes          *int `json:"gigabytes,omitempty"`
+	PerVolumeGigabytes *int `json:"per_volume_gigabytes,omitempty"`
+	Backups            *int `json:"backups,omitempty"`
+	BackupGigabytes    *int `json:"backup_gigabytes,omitempty"`
+	Groups             *int `json:"groups,omitempty"`
+}
+
+// UpdateNetworkQuotaOpts contains the Neutron quotas to change; nil fields are left untouched
+type UpdateNetworkQuotaOpts struct {
+	Network           *int `json:"network,omitempty"`
+	Subnet            *int `json:"subnet,omitempty"`
+	Port              *int `json:"port,omitempty"`
+	Router            *int `json:"router,omitempty"`
+	FloatingIP        *int `json:"floatingip,omitempty"`
+	SecurityGroup     *int `json:"security_group,omitempty"`
+	SecurityGroupRule *int `json:"security_group_rule,omitempty"`
+}
+
+// GetProjectQuotaUsage gathers Nova, Cinder and Neutron quota usage of a
+// project. An empty projectID means the current project. A failing service
+// is reported in Errors instead of failing the whole request.
+func (c *Client) GetProjectQuotaUsage(ctx context.Context, projectID string) (*ProjectQuotaUsage, error) {
+	if projectID == "" {
+		projectID = c.projectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("project ID is unknown, specify it explicitly")
+	}
+
+	log.Debug().
+		Str("project_id", projectID).
+		Msg("Getting project quota usage")
+
+	result := &ProjectQuotaUsage{
+		ProjectID: projectID,
+		Errors:    map[string]string{},
+	}
+
+	collectors := []struct {
+		service string
+		collect func(context.Context, string) ([]QuotaUsage, error)
+	}{
+		{QuotaServiceCompute, c.computeQuotaUsage},
+		{QuotaServiceBlockStorage, c.blockStorageQuotaUsage},
+		{QuotaServiceNetwork, c.networkQuotaUsage},
+	}
+	for _, collector := range collectors {
+		usage, err := collector.collect(ctx, projectID)
+		if err != nil {
+			log.Warn().Err(err).Str("service", collector.service).Msg("Failed to get quota usage")
+			result.Errors[collector.service] = err.Error()
+			continue
+		}
+		result.Usage = append(result.Usage, usage...)
+	}
+
+	if len(result.Errors) == len(collectors) {
+		return nil, fmt.Errorf("no service returned quota usage: %v", result.Errors)
+	}
+
+	return result, nil
+}
+
+// computeQuotaUsage reads Nova absolute limits, which only cover the current project
+func (c *Client) computeQuotaUsage(ctx context.Context, projectID string) ([]QuotaUsage, error) {
+	if c.computeV2 == nil {
+		return nil, fmt.Errorf("compute client not initialized")
+	}
+
+	var opts limits.GetOpts
+	if projectID != c.projectID {
+		opts.TenantID = projectID
+	}
+
+	l, err := limits.Get(ctx, c.computeV2, opts).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting compute limits: %w", err)
+	}
+
+	a := l.Absolute
+	return []QuotaUsage{
+		newQuotaUsage(QuotaServiceCompute, "cores", a.MaxTotalCores, a.TotalCoresUsed, 0),
+		newQuotaUsage(QuotaServiceCompute, "instances", a.MaxTotalInstances, a.TotalInstancesUsed, 0),
+		newQuotaUsage(QuotaServiceCompute, "ram", a.MaxTotalRAMSize, a.TotalRAMUsed, 0),
+		newQuotaUsage(QuotaServiceCompute, "server_groups", a.MaxServerGroups, a.TotalServerGroupsUsed, 0),
+	}, nil
+}
+
+// blockStorageQuotaUsage reads the Cinder quota set usage, including per volume type entries
+func (c *Client) blockStorageQuotaUsage(ctx context.Context, projectID string) ([]QuotaUsage, error) {
+	if c.blockStorageV3 == nil {
+		return nil, fmt.Errorf("block storage client not initialized")
+	}
+
+	// Decode generically to keep the volumes_<type>/gigabytes_<type> entries
+	var body struct {
+		QuotaSet map[string]json.RawMessage `json:"quota_set"`
+	}
+	if err := blockquotas.GetUsage(ctx, c.blockStorageV3, projectID).ExtractInto(&body); err != nil {
+		return nil, fmt.Errorf("getting block storage quota usage: %w", err)
+	}
+
+	var result []QuotaUsage
+	for resource, raw := range body.QuotaSet {
+		var u blockquotas.QuotaUsage
+		if err := json.Unmarshal(raw, &u); err != nil {
+			continue // "id" and other non-usage fields
+		}
+		result = append(result, newQuotaUsage(QuotaServiceBlockStorage, resource, u.Limit, u.InUse, u.Reserved))
+	}
+	sortQuotaUsage(result)
+	return result, nil
+}
+
+// networkQuotaUsage reads the Neutron quota details
+func (c *Client) networkQuotaUsage(ctx context.Context, projectID string) ([]QuotaUsage, error) {
+	if c.networkV2 == nil {
+		return nil, fmt.Errorf("network client not initialized")
+	}
+
+	var body struct {
+		Quota map[string]networkquotas.QuotaDetail `json:"quota"`
+	}
+	if err := networkquotas.GetDetail(ctx, c.networkV2, projectID).ExtractInto(&body); err != nil {
+		return nil, fmt.Errorf("getting network quota details: %w", err)
+	}
+
+	result := make([]QuotaUsage, 0, len(body.Quota))
+	for resource, d := range body.Quota {
+		result = append(result, newQuotaUsage(QuotaServiceNetwork, resource, d.Limit, d.Used, d.Reserved))
+	}
+	sortQuotaUsage(result)
+	return result, nil
+}
+
+// UpdateComputeQuota updates the Nova quotas of a project (admin only)
+func (c *Client) UpdateComputeQuota(ctx context.Context, projectID string, opts UpdateComputeQuotaOpts) (*QuotaSet, error) {
+	if c.computeV2 == nil {
+		return nil, fmt.Errorf("compute client not initialized")
+	}
+
+	log.Info().
+		Str("project_id", projectID).
+		Msg("Updating compute quota")
+
+	updateOpts := computequotas.UpdateOpts{
+		Instances:          opts.Instances,
+		Cores:              opts.Cores,
+		RAM:                opts.RAM,
+		KeyPairs:           opts.KeyPairs,
+		ServerGroups:       opts.ServerGroups,
+		ServerGroupMembers: opts.ServerGroupMembers,
+		MetadataItems:      opts.MetadataItems,
+	}
+
+	var body struct {
+		QuotaSet map[string]json.RawMessage `json:"quota_set"`
+	}
+	if err := computequotas.Update(ctx, c.computeV2, projectID, updateOpts).ExtractInto(&body); err != nil {
+		return nil, fmt.Errorf("updating compute quota of project %s: %w", projectID, err)
+	}
+	return newQuotaSet(QuotaServiceCompute, projectID, body.QuotaSet), nil
+}
+
+// UpdateBlockStorageQuota updates the Cinder quotas of a project (admin only)
+func (c *Client) UpdateBlockStorageQuota(ctx context.Context, projectID string, opts UpdateBlockStorageQuotaOpts) (*QuotaSet, error) {
+	if c.blockStorageV3 == nil {
+		return nil, fmt.Errorf("block storage client not initialized")
+	}
+
+	log.Info().
+		Str("project_id", projectID).
+		Msg("Updating block storage quota")
+
+	updateOpts := blockquotas.UpdateOpts{
+		Volumes:            opts.Volumes,
+		Snapshots:          opts.Snapshots,
+		Gigabytes:          opts.Gigabytes,
+		PerVolumeGigabytes: opts.PerVolumeGigabytes,
+		Backups:            opts.Backups,
+		BackupGigabytes:    opts.BackupGigabytes,
+		Groups:             opts.Groups,
+	}
+
+	var body struct {
+		QuotaSet map[string]json.RawMessage `json:"quota_set"`
+	}
+	if err := blockquotas.Update(ctx, c.blockStorageV3, projectID, updateOpts).ExtractInto(&body); err != nil {
+		return nil, fmt.Errorf("updating block storage quota of project %s: %w", projectID, err)
+	}
+	return newQuotaSet(QuotaServiceBlockStorage, projectID, body.QuotaSet), nil
+}
+
+// UpdateNetworkQuota updates the Neutron quotas of a project (admin only)
+func (c *Client) UpdateNetworkQuota(ctx context.Context, projectID string, opts UpdateNetworkQuotaOpts) (*QuotaSet, error) {
+	if c.networkV2 == nil {
+		return nil, fmt.Errorf("network client not initialized")
+	}
+
+	log.Info().
+		Str("project_id", projectID).
+		Msg("Updating network quota")
+
+	updateOpts := networkquotas.UpdateOpts{
+		Network:           opts.Network,
+		Subnet:            opts.Subnet,
+		Port:              opts.Port,
+		Router:            opts.Router,
+		FloatingIP:        opts.FloatingIP,
+		SecurityGroup:     opts.SecurityGroup,
+		SecurityGroupRule: opts.SecurityGroupRule,
+	}
+
+	var body struct {
+		Quota map[string]json.RawMessage `json:"quota"`
+	}
+	if err := networkquotas.Update(ctx, c.networkV2, projectID, updateOpts).ExtractInto(&body); err != nil {
+		return nil, fmt.Errorf("updating network quota of project %s: %w", projectID, err)
+	}
+	return newQuotaSet(QuotaServiceNetwork, projectID, body.Quota), nil
+}
+
+// newQuotaSet builds a QuotaSet from a generically decoded quota set,
+// skipping "id" and other non-limit fields
+func newQuotaSet(service, projectID string, quotaSet map[string]json.RawMessage) *QuotaSet {
+	result := &QuotaSet{ProjectID: projectID, Service: service, Limits: map[string]int{}}
+	for resource, raw := range quotaSet {
+		var limit int
+		if err := json.Unmarshal(raw, &limit); err == nil {
+			result.Limits[resource] = limit
+		}
+	}
+	return result
+}
+
+// newQuotaUsage builds a QuotaUsage, computing the remaining amount
+func newQuotaUsage(service, resource string, limit, inUse, reserved int) QuotaUsage {
+	remaining := -1
+	if limit >= 0 {
+		remaining = limit - inUse - reserved
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+	return QuotaUsage{
+		Service:   service,
+		Resource:  resource,
+		Limit:     limit,
+		InUse:     inUse,
+		Reserved:  reserved,
+		Remaining: remaining,
+	}
+}
+
+// sortQuotaUsage orders usage entries by resource name for stable output
+func sortQuotaUsage(usage []QuotaUsage) {
+	sort.Slice(usage, func(a, b int) bool {
+		return usage[a].Resource < usage[b].Resource
+	})
+}
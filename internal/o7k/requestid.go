@@ -0,0 +1,65 @@
+package o7k
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// RequestIDs collects the OpenStack request IDs of the API calls made on
+// behalf of one tool call, for correlating audit records with service logs
+type RequestIDs struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+// requestIDsContextKey stores the RequestIDs collector in a context
+type requestIDsContextKey struct{}
+
+// WithRequestIDs returns a context collecting the request IDs of the
+// OpenStack API calls made with it
+func WithRequestIDs(ctx context.Context) (context.Context, *RequestIDs) {
+	ids := &RequestIDs{}
+	return context.WithValue(ctx, requestIDsContextKey{}, ids), ids
+}
+
+// List returns the collected request IDs in call order
+func (r *RequestIDs) List() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+// add records a request ID
+func (r *RequestIDs) add(id string) {
+	r.mu.Lock()
+	r.ids = append(r.ids, id)
+	r.mu.Unlock()
+}
+
+// requestIDTransport records the request ID header of every response in
+// the collector of the request's context
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip performs the request and records its OpenStack request ID
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	ids, ok := req.Context().Value(requestIDsContextKey{}).(*RequestIDs)
+	if !ok {
+		return resp, nil
+	}
+	// Nova still answers with its own header name
+	for _, header := range []string{"X-Openstack-Request-Id", "X-Compute-Request-Id"} {
+		if id := resp.Header.Get(header); id != "" {
+			ids.add(id)
+			break
+		}
+	}
+	return resp, nil
+}
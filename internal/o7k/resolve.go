@@ -0,0 +1,178 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ResourceKind is a kind of OpenStack resource referred to by name or ID
+type ResourceKind string
+
+// Resource kinds
+const (
+	ResourceVolume            ResourceKind = "volume"
+	ResourceVolumeType        ResourceKind = "volume type"
+	ResourceServer            ResourceKind = "server"
+	ResourceNetwork           ResourceKind = "network"
+	ResourceShare             ResourceKind = "share"
+	ResourceShareNetwork      ResourceKind = "share network"
+	ResourceShareSnapshot     ResourceKind = "share snapshot"
+	ResourceBaremetalNode     ResourceKind = "bare metal node"
+	ResourceCluster           ResourceKind = "cluster"
+	ResourceClusterTemplate   ResourceKind = "cluster template"
+	ResourcePlacementProvider ResourceKind = "resource provider"
+)
+
+// NamedResource is the ID and name of a resource
+type NamedResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AmbiguousNameError is returned when several resources share the name
+// given for one
+type AmbiguousNameError struct {
+	Kind       ResourceKind
+	Name       string
+	Candidates []NamedResource
+}
+
+func (e *AmbiguousNameError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		ids[i] = candidate.ID
+	}
+	return fmt.Sprintf("%d %ss are named %q, use the ID of one of them instead: %s",
+		len(e.Candidates), e.Kind, e.Name, strings.Join(ids, ", "))
+}
+
+// ListNamedResources lists the IDs and names of the resources of a kind
+func (c *Client) ListNamedResources(ctx context.Context, kind ResourceKind) ([]NamedResource, error) {
+	switch kind {
+	case ResourceVolume:
+		volumes, err := c.ListVolumes(ctx)
+		return namedResources(volumes, err, func(v Volume) NamedResource { return NamedResource{v.ID, v.Name} })
+	case ResourceVolumeType:
+		types, err := c.ListVolumeTypes(ctx)
+		return namedResources(types, err, func(t VolumeType) NamedResource { return NamedResource{t.ID, t.Name} })
+	case ResourceServer:
+		servers, err := c.ListServers(ctx)
+		return namedResources(servers, err, func(s Server) NamedResource { return NamedResource{s.ID, s.Name} })
+	case ResourceNetwork:
+		networks, err := c.ListNetworks(ctx)
+		return namedResources(networks, err, func(n Network) NamedResource { return NamedResource{n.ID, n.Name} })
+	case ResourceShare:
+		shares, err := c.ListShares(ctx)
+		return namedResources(shares, err, func(s Share) NamedResource { return NamedResource{s.ID, s.Name} })
+	case ResourceShareNetwork:
+		networks, err := c.ListShareNetworks(ctx)
+		return namedResources(networks, err, func(n ShareNetwork) NamedResource { return NamedResource{n.ID, n.Name} })
+	case ResourceShareSnapshot:
+		snapshots, err := c.ListShareSnapshots(ctx, "")
+		return namedResources(snapshots, err, func(s ShareSnapshot) NamedResource { return NamedResource{s.ID, s.Name} })
+	case ResourceBaremetalNode:
+		nodes, err := c.ListBaremetalNodes(ctx, ListBaremetalNodesOpts{})
+		return namedResources(nodes, err, func(n BaremetalNode) NamedResource { return NamedResource{n.ID, n.Name} })
+	case ResourceCluster:
+		clusters, err := c.ListClusters(ctx)
+		return namedResources(clusters, err, func(cl Cluster) NamedResource { return NamedResource{cl.ID, cl.Name} })
+	case ResourceClusterTemplate:
+		templates, err := c.ListClusterTemplates(ctx)
+		return namedResources(templates, err, func(t ClusterTemplate) NamedResource { return NamedResource{t.ID, t.Name} })
+	case ResourcePlacementProvider:
+		providers, err := c.ListResourceProviders(ctx, ListResourceProvidersOpts{})
+		return namedResources(providers, err, func(p ResourceProvider) NamedResource { return NamedResource{p.ID, p.Name} })
+	}
+	return nil, fmt.Errorf("unknown resource kind %q", kind)
+}
+
+// namedResources converts a listing to named resources
+func namedResources[T any](items []T, err error, convert func(T) NamedResource) ([]NamedResource, error) {
+	if err != nil {
+		return nil, err
+	}
+	result := make([]NamedResource, len(items))
+	for i, item := range items {
+		result[i] = convert(item)
+	}
+	return result, nil
+}
+
+// Resolve returns the resource of a kind that nameOrID names, or nil when
+// nameOrID is an ID. UUIDs are taken as IDs without a lookup, so that a
+// missing resource fails with the service's own error; those written in
+// upper case or without hyphens resolve to their canonical form, the one
+// OpenStack returns. Other values are matched exactly against the names,
+// then the IDs, of the resources listed. A name several resources share is
+// an AmbiguousNameError.
+func (c *Client) Resolve(ctx context.Context, kind ResourceKind, nameOrID string) (*NamedResource, error) {
+	if id, ok := canonicalUUID(nameOrID); ok {
+		if id == nameOrID {
+			return nil, nil
+		}
+		return &NamedResource{ID: id}, nil
+	}
+
+	all, err := c.ListNamedResources(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("listing %ss: %w", kind, err)
+	}
+
+	resource, err := matchName(kind, nameOrID, all)
+	if resource != nil {
+		log.Debug().
+			Str("kind", string(kind)).
+			Str("name", nameOrID).
+			Str("id", resource.ID).
+			Msg("Resolved resource name")
+	}
+	return resource, err
+}
+
+// matchName finds the resource nameOrID names among those listed, or nil
+// when it is the ID of one of them
+func matchName(kind ResourceKind, nameOrID string, all []NamedResource) (*NamedResource, error) {
+	var matches []NamedResource
+	for _, resource := range all {
+		if resource.Name == nameOrID {
+			matches = append(matches, resource)
+		}
+	}
+	if len(matches) == 1 {
+		return &matches[0], nil
+	}
+	if len(matches) > 1 {
+		sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+		return nil, &AmbiguousNameError{Kind: kind, Name: nameOrID, Candidates: matches}
+	}
+
+	for _, resource := range all {
+		if resource.ID == nameOrID {
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s has the name or ID %q", kind, nameOrID)
+}
+
+// canonicalUUID returns s in lower case with hyphens when it is a UUID,
+// with or without hyphens and in either case
+func canonicalUUID(s string) (string, bool) {
+	hex := strings.ToLower(strings.ReplaceAll(s, "-", ""))
+	if len(hex) != 32 || (len(s) != 32 && len(s) != 36) {
+		return "", false
+	}
+	for _, r := range hex {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", false
+		}
+	}
+	id := hex[:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:]
+	if len(s) == 36 && !strings.EqualFold(s, id) {
+		return "", false
+	}
+	return id, true
+}
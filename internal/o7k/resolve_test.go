@@ -0,0 +1,91 @@
+package o7k
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestCanonicalUUID checks which values are taken as IDs without a lookup,
+// and the canonical form they resolve to
+func TestCanonicalUUID(t *testing.T) {
+	const id = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{id, id, true},
+		{"3FA85F64-5717-4562-B3FC-2C963F66AFA6", id, true},
+		{"3fa85f6457174562b3fc2c963f66afa6", id, true},
+		{"3FA85F6457174562B3FC2C963F66AFA6", id, true},
+		{"3fa85f64-5717-4562-b3fc-2c963f66afa", "", false},
+		{"3fa85f64-5717-4562-b3fc-2c963f66afag", "", false},
+		{"3fa8-5f64-5717-4562-b3fc-2c963f66afa6", "", false},
+		{"3fa85f645-717-4562-b3fc-2c963f66afa6", "", false},
+		{"web-1", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := canonicalUUID(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("canonicalUUID(%q) = %q, %t, want %q, %t", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestMatchName checks that names resolve to a single resource, that IDs
+// are passed on, and that shared and unknown names are rejected
+func TestMatchName(t *testing.T) {
+	all := []NamedResource{
+		{ID: "vol-1", Name: "data"},
+		{ID: "vol-3", Name: "dup"},
+		{ID: "vol-2", Name: "dup"},
+		{ID: "vol-4", Name: "vol-1"},
+		{ID: "vol-5", Name: ""},
+	}
+
+	tests := []struct {
+		name       string
+		nameOrID   string
+		wantID     string
+		wantErr    bool
+		candidates []string
+	}{
+		{"unique name", "data", "vol-1", false, nil},
+		{"ID", "vol-2", "", false, nil},
+		{"name preferred over ID", "vol-1", "vol-4", false, nil},
+		{"shared name", "dup", "", true, []string{"vol-2", "vol-3"}},
+		{"unknown", "logs", "", true, nil},
+		{"case differs", "Data", "", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := matchName(ResourceVolume, tt.nameOrID, all)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchName(%q) error = %v, want error: %t", tt.nameOrID, err, tt.wantErr)
+			}
+			gotID := ""
+			if resource != nil {
+				gotID = resource.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("matchName(%q) = %q, want %q", tt.nameOrID, gotID, tt.wantID)
+			}
+
+			var ambiguous *AmbiguousNameError
+			if errors.As(err, &ambiguous) != (tt.candidates != nil) {
+				t.Fatalf("matchName(%q) error = %v, want ambiguous: %t", tt.nameOrID, err, tt.candidates != nil)
+			}
+			if ambiguous != nil {
+				ids := make([]string, len(ambiguous.Candidates))
+				for i, candidate := range ambiguous.Candidates {
+					ids[i] = candidate.ID
+				}
+				if !slices.Equal(ids, tt.candidates) {
+					t.Errorf("candidates = %v, want %v", ids, tt.candidates)
+				}
+			}
+		})
+	}
+}
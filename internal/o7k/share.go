@@ -0,0 +1,518 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/sharedfilesystems/v2/sharenetworks"
+	"github.com/gophercloud/gophercloud/v2/openstack/sharedfilesystems/v2/shares"
+	"github.com/gophercloud/gophercloud/v2/openstack/sharedfilesystems/v2/snapshots"
+	"github.com/rs/zerolog/log"
+)
+
+// Share represents an OpenStack shared file system (Manila share)
+type Share struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Size             int               `json:"size"`        // Size in GB
+	Status           string            `json:"status"`      // creating, available, extending, etc.
+	ShareProto       string            `json:"share_proto"` // NFS, CIFS, CEPHFS, etc.
+	ShareType        string            `json:"share_type"`
+	ShareTypeName    string            `json:"share_type_name"`
+	ShareNetworkID   string            `json:"share_network_id,omitempty"`
+	AvailabilityZone string            `json:"availability_zone"`
+	SnapshotID       string            `json:"snapshot_id,omitempty"`
+	IsPublic         bool              `json:"is_public"`
+	Metadata         map[string]string `json:"metadata"`
+	CreatedAt        string            `json:"created_at"`
+	UpdatedAt        string            `json:"updated_at"`
+}
+
+// ShareExportLocation represents a path through which a share can be mounted
+type ShareExportLocation struct {
+	ID          string `json:"id"`
+	Path        string `json:"path"`
+	Preferred   bool   `json:"preferred"`
+	IsAdminOnly bool   `json:"is_admin_only"`
+}
+
+// ShareAccessRule represents an access rule granted on a share
+type ShareAccessRule struct {
+	ID          string `json:"id"`
+	ShareID     string `json:"share_id"`
+	AccessType  string `json:"access_type"`  // ip, cert, user, cephx
+	AccessTo    string `json:"access_to"`    // IP/CIDR, certificate CN or user name
+	AccessLevel string `json:"access_level"` // rw or ro
+	State       string `json:"state"`
+}
+
+// ShareSnapshot represents a snapshot of a share
+type ShareSnapshot struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ShareID     string `json:"share_id"`
+	ShareProto  string `json:"share_proto"`
+	ShareSize   int    `json:"share_size"` // Size of the source share in GB
+	Size        int    `json:"size"`       // Size in GB
+	Status      string `json:"status"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// CreateShareOpts contains options for creating a share
+type CreateShareOpts struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
+	Size             int               `json:"size"`        // Size in GB (required)
+	ShareProto       string            `json:"share_proto"` // Protocol (required)
+	ShareType        string            `json:"share_type,omitempty"`
+	ShareNetworkID   string            `json:"share_network_id,omitempty"`
+	SnapshotID       string            `json:"snapshot_id,omitempty"`
+	AvailabilityZone string            `json:"availability_zone,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// GrantShareAccessOpts contains options for granting access to a share
+type GrantShareAccessOpts struct {
+	AccessType  string `json:"access_type"`
+	AccessTo    string `json:"access_to"`
+	AccessLevel string `json:"access_level"`
+}
+
+// CreateShareSnapshotOpts contains options for creating a share snapshot
+type CreateShareSnapshotOpts struct {
+	ShareID     string `json:"share_id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListShares lists all shares accessible to the current project
+func (c *Client) ListShares(ctx context.Context) ([]Share, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().Msg("Listing shares")
+
+	allPages, err := shares.ListDetail(c.sharedFileSystemsV2, shares.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing shares: %w", err)
+	}
+
+	allShares, err := shares.ExtractShares(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting shares: %w", err)
+	}
+
+	result := make([]Share, len(allShares))
+	for i, share := range allShares {
+		result[i] = *convertShare(&share)
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed shares")
+	return result, nil
+}
+
+// GetShare retrieves a share by ID
+func (c *Client) GetShare(ctx context.Context, shareID string) (*Share, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().
+		Str("share_id", shareID).
+		Msg("Getting share")
+
+	share, err := shares.Get(ctx, c.sharedFileSystemsV2, shareID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting share %s: %w", shareID, err)
+	}
+
+	return convertShare(share), nil
+}
+
+// CreateShare creates a new share in OpenStack
+func (c *Client) CreateShare(ctx context.Context, opts CreateShareOpts) (*Share, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("name", opts.Name).
+		Int("size", opts.Size).
+		Str("share_proto", opts.ShareProto).
+		Str("share_type", opts.ShareType).
+		Msg("Creating share")
+
+	createOpts := shares.CreateOpts{
+		Name:             opts.Name,
+		Description:      opts.Description,
+		Size:             opts.Size,
+		ShareProto:       opts.ShareProto,
+		ShareType:        opts.ShareType,
+		ShareNetworkID:   opts.ShareNetworkID,
+		SnapshotID:       opts.SnapshotID,
+		AvailabilityZone: opts.AvailabilityZone,
+		Metadata:         opts.Metadata,
+	}
+
+	share, err := shares.Create(ctx, c.sharedFileSystemsV2, createOpts).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("creating share: %w", err)
+	}
+
+	log.Info().
+		Str("id", share.ID).
+		Str("name", share.Name).
+		Msg("Share created successfully")
+
+	return convertShare(share), nil
+}
+
+// DeleteShare deletes a share by ID
+func (c *Client) DeleteShare(ctx context.Context, shareID string) error {
+	if c.sharedFileSystemsV2 == nil {
+		return fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("share_id", shareID).
+		Msg("Deleting share")
+
+	err := shares.Delete(ctx, c.sharedFileSystemsV2, shareID).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("deleting share %s: %w", shareID, err)
+	}
+
+	log.Info().
+		Str("share_id", shareID).
+		Msg("Share deleted successfully")
+
+	return nil
+}
+
+// ExtendShare grows a share to the given size in GB
+func (c *Client) ExtendShare(ctx context.Context, shareID string, newSize int) error {
+	if c.sharedFileSystemsV2 == nil {
+		return fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("share_id", shareID).
+		Int("new_size", newSize).
+		Msg("Extending share")
+
+	err := shares.Extend(ctx, c.sharedFileSystemsV2, shareID, shares.ExtendOpts{NewSize: newSize}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("extending share %s: %w", shareID, err)
+	}
+
+	return nil
+}
+
+// ShrinkShare reduces a share to the given size in GB
+func (c *Client) ShrinkShare(ctx context.Context, shareID string, newSize int) error {
+	if c.sharedFileSystemsV2 == nil {
+		return fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("share_id", shareID).
+		Int("new_size", newSize).
+		Msg("Shrinking share")
+
+	err := shares.Shrink(ctx, c.sharedFileSystemsV2, shareID, shares.ShrinkOpts{NewSize: newSize}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("shrinking share %s: %w", shareID, err)
+	}
+
+	return nil
+}
+
+// ListShareExportLocations lists the export locations of a share
+func (c *Client) ListShareExportLocations(ctx context.Context, shareID string) ([]ShareExportLocation, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().
+		Str("share_id", shareID).
+		Msg("Listing share export locations")
+
+	locations, err := shares.ListExportLocations(ctx, c.sharedFileSystemsV2, shareID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("listing export locations of share %s: %w", shareID, err)
+	}
+
+	result := make([]ShareExportLocation, len(locations))
+	for i, loc := range locations {
+		result[i] = ShareExportLocation{
+			ID:          loc.ID,
+			Path:        loc.Path,
+			Preferred:   loc.Preferred,
+			IsAdminOnly: loc.IsAdminOnly,
+		}
+	}
+
+	return result, nil
+}
+
+// ListShareAccessRules lists the access rules of a share
+func (c *Client) ListShareAccessRules(ctx context.Context, shareID string) ([]ShareAccessRule, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().
+		Str("share_id", shareID).
+		Msg("Listing share access rules")
+
+	rules, err := shares.ListAccessRights(ctx, c.sharedFileSystemsV2, shareID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("listing access rules of share %s: %w", shareID, err)
+	}
+
+	result := make([]ShareAccessRule, len(rules))
+	for i, rule := range rules {
+		result[i] = *convertShareAccessRule(&rule, shareID)
+	}
+
+	return result, nil
+}
+
+// GrantShareAccess grants access to a share
+func (c *Client) GrantShareAccess(ctx context.Context, shareID string, opts GrantShareAccessOpts) (*ShareAccessRule, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("share_id", shareID).
+		Str("access_type", opts.AccessType).
+		Str("access_to", opts.AccessTo).
+		Str("access_level", opts.AccessLevel).
+		Msg("Granting share access")
+
+	grantOpts := shares.GrantAccessOpts{
+		AccessType:  opts.AccessType,
+		AccessTo:    opts.AccessTo,
+		AccessLevel: opts.AccessLevel,
+	}
+
+	rule, err := shares.GrantAccess(ctx, c.sharedFileSystemsV2, shareID, grantOpts).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("granting access to share %s: %w", shareID, err)
+	}
+
+	return convertShareAccessRule(rule, shareID), nil
+}
+
+// RevokeShareAccess revokes an access rule from a share
+func (c *Client) RevokeShareAccess(ctx context.Context, shareID, accessID string) error {
+	if c.sharedFileSystemsV2 == nil {
+		return fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("share_id", shareID).
+		Str("access_id", accessID).
+		Msg("Revoking share access")
+
+	err := shares.RevokeAccess(ctx, c.sharedFileSystemsV2, shareID, shares.RevokeAccessOpts{AccessID: accessID}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("revoking access %s from share %s: %w", accessID, shareID, err)
+	}
+
+	return nil
+}
+
+// ListShareSnapshots lists share snapshots, optionally filtered by share ID
+func (c *Client) ListShareSnapshots(ctx context.Context, shareID string) ([]ShareSnapshot, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().
+		Str("share_id", shareID).
+		Msg("Listing share snapshots")
+
+	allPages, err := snapshots.ListDetail(c.sharedFileSystemsV2, snapshots.ListOpts{ShareID: shareID}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing share snapshots: %w", err)
+	}
+
+	allSnapshots, err := snapshots.ExtractSnapshots(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting share snapshots: %w", err)
+	}
+
+	result := make([]ShareSnapshot, len(allSnapshots))
+	for i, snap := range allSnapshots {
+		result[i] = *convertShareSnapshot(&snap)
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed share snapshots")
+	return result, nil
+}
+
+// GetShareSnapshot retrieves a share snapshot by ID
+func (c *Client) GetShareSnapshot(ctx context.Context, snapshotID string) (*ShareSnapshot, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().
+		Str("snapshot_id", snapshotID).
+		Msg("Getting share snapshot")
+
+	snap, err := snapshots.Get(ctx, c.sharedFileSystemsV2, snapshotID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("getting share snapshot %s: %w", snapshotID, err)
+	}
+
+	return convertShareSnapshot(snap), nil
+}
+
+// CreateShareSnapshot creates a snapshot of a share
+func (c *Client) CreateShareSnapshot(ctx context.Context, opts CreateShareSnapshotOpts) (*ShareSnapshot, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("share_id", opts.ShareID).
+		Str("name", opts.Name).
+		Msg("Creating share snapshot")
+
+	createOpts := snapshots.CreateOpts{
+		ShareID:     opts.ShareID,
+		Name:        opts.Name,
+		Description: opts.Description,
+	}
+
+	snap, err := snapshots.Create(ctx, c.sharedFileSystemsV2, createOpts).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("creating share snapshot: %w", err)
+	}
+
+	log.Info().
+		Str("id", snap.ID).
+		Str("share_id", snap.ShareID).
+		Msg("Share snapshot created successfully")
+
+	return convertShareSnapshot(snap), nil
+}
+
+// DeleteShareSnapshot deletes a share snapshot by ID
+func (c *Client) DeleteShareSnapshot(ctx context.Context, snapshotID string) error {
+	if c.sharedFileSystemsV2 == nil {
+		return fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Info().
+		Str("snapshot_id", snapshotID).
+		Msg("Deleting share snapshot")
+
+	err := snapshots.Delete(ctx, c.sharedFileSystemsV2, snapshotID).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("deleting share snapshot %s: %w", snapshotID, err)
+	}
+
+	return nil
+}
+
+// ShareNetwork represents a Manila share network
+type ShareNetwork struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	NeutronNetID    string `json:"neutron_net_id,omitempty"`
+	NeutronSubnetID string `json:"neutron_subnet_id,omitempty"`
+}
+
+// ListShareNetworks lists the share networks of the current project
+func (c *Client) ListShareNetworks(ctx context.Context) ([]ShareNetwork, error) {
+	if c.sharedFileSystemsV2 == nil {
+		return nil, fmt.Errorf("shared file systems client not initialized")
+	}
+
+	log.Debug().Msg("Listing share networks")
+
+	allPages, err := sharenetworks.ListDetail(c.sharedFileSystemsV2, sharenetworks.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing share networks: %w", err)
+	}
+
+	allNetworks, err := sharenetworks.ExtractShareNetworks(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting share networks: %w", err)
+	}
+
+	result := make([]ShareNetwork, len(allNetworks))
+	for i, network := range allNetworks {
+		result[i] = ShareNetwork{
+			ID:              network.ID,
+			Name:            network.Name,
+			Description:     network.Description,
+			NeutronNetID:    network.NeutronNetID,
+			NeutronSubnetID: network.NeutronSubnetID,
+		}
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed share networks")
+	return result, nil
+}
+
+// convertShare converts a Gophercloud share to our Share type
+func convertShare(share *shares.Share) *Share {
+	return &Share{
+		ID:               share.ID,
+		Name:             share.Name,
+		Description:      share.Description,
+		Size:             share.Size,
+		Status:           share.Status,
+		ShareProto:       share.ShareProto,
+		ShareType:        share.ShareType,
+		ShareTypeName:    share.ShareTypeName,
+		ShareNetworkID:   share.ShareNetworkID,
+		AvailabilityZone: share.AvailabilityZone,
+		SnapshotID:       share.SnapshotID,
+		IsPublic:         share.IsPublic,
+		Metadata:         share.Metadata,
+		CreatedAt:        share.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:        share.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// convertShareAccessRule converts a Gophercloud access right to our ShareAccessRule type
+func convertShareAccessRule(rule *shares.AccessRight, shareID string) *ShareAccessRule {
+	result := &ShareAccessRule{
+		ID:          rule.ID,
+		ShareID:     rule.ShareID,
+		AccessType:  rule.AccessType,
+		AccessTo:    rule.AccessTo,
+		AccessLevel: rule.AccessLevel,
+		State:       rule.State,
+	}
+	// The access_list action does not echo the share ID back
+	if result.ShareID == "" {
+		result.ShareID = shareID
+	}
+	return result
+}
+
+// convertShareSnapshot converts a Gophercloud share snapshot to our ShareSnapshot type
+func convertShareSnapshot(snap *snapshots.Snapshot) *ShareSnapshot {
+	return &ShareSnapshot{
+		ID:          snap.ID,
+		Name:        snap.Name,
+		Description: snap.Description,
+		ShareID:     snap.ShareID,
+		ShareProto:  snap.ShareProto,
+		ShareSize:   snap.ShareSize,
+		Size:        snap.Size,
+		Status:      snap.Status,
+		CreatedAt:   snap.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,76 @@
+package o7k
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+	"github.com/jneo8/openstack-mcp-server/internal/config"
+)
+
+// ValidatedToken describes a Keystone token presented by an MCP caller
+type ValidatedToken struct {
+	UserID      string
+	UserName    string
+	ProjectID   string
+	ProjectName string
+	Roles       []string
+	ExpiresAt   time.Time
+}
+
+// ValidateToken checks a caller's Keystone token against the Keystone of the
+// default cloud. The token validates itself, so no service credentials or
+// admin role are needed.
+func (s *ClientSet) ValidateToken(ctx context.Context, token string) (*ValidatedToken, error) {
+	cfg := s.configs[config.DefaultCloudName]
+
+	transport, err := newHTTPTransport(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
+
+	provider, err := openstack.NewClient(cfg.AuthURL)
+	if err != nil {
+		return nil, fmt.Errorf("creating provider client: %w", err)
+	}
+	provider.HTTPClient = http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	}
+	provider.SetToken(token)
+
+	identity, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("creating identity v3 client: %w", err)
+	}
+
+	result := tokens.Get(ctx, identity, token)
+	if result.Err != nil {
+		return nil, fmt.Errorf("validating token: %w", result.Err)
+	}
+
+	t, err := result.ExtractToken()
+	if err != nil {
+		return nil, fmt.Errorf("extracting token: %w", err)
+	}
+	validated := &ValidatedToken{ExpiresAt: t.ExpiresAt}
+	if user, err := result.ExtractUser(); err == nil && user != nil {
+		validated.UserID = user.ID
+		validated.UserName = user.Name
+	}
+	if project, err := result.ExtractProject(); err == nil && project != nil {
+		validated.ProjectID = project.ID
+		validated.ProjectName = project.Name
+	}
+	if roles, err := result.ExtractRoles(); err == nil {
+		for _, role := range roles {
+			validated.Roles = append(validated.Roles, role.Name)
+		}
+	}
+
+	return validated, nil
+}
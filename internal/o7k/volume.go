@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumetypes"
 	"github.com/rs/zerolog/log"
 )
 
@@ -117,6 +118,46 @@ func (c *Client) ListVolumes(ctx context.Context) ([]Volume, error) {
 	return result, nil
 }
 
+// VolumeType represents a Cinder volume type
+type VolumeType struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// ListVolumeTypes lists the volume types available to the current project
+func (c *Client) ListVolumeTypes(ctx context.Context) ([]VolumeType, error) {
+	if c.blockStorageV3 == nil {
+		return nil, fmt.Errorf("block storage client not initialized")
+	}
+
+	log.Debug().Msg("Listing volume types")
+
+	allPages, err := volumetypes.List(c.blockStorageV3, volumetypes.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing volume types: %w", err)
+	}
+
+	allTypes, err := volumetypes.ExtractVolumeTypes(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("extracting volume types: %w", err)
+	}
+
+	result := make([]VolumeType, len(allTypes))
+	for i, vt := range allTypes {
+		result[i] = VolumeType{
+			ID:          vt.ID,
+			Name:        vt.Name,
+			Description: vt.Description,
+			IsPublic:    vt.PublicAccess,
+		}
+	}
+
+	log.Debug().Int("count", len(result)).Msg("Listed volume types")
+	return result, nil
+}
+
 // UpdateVolume updates a volume's metadata
 func (c *Client) UpdateVolume(ctx context.Context, volumeID string, opts UpdateVolumeOpts) (*Volume, error) {
 	if c.blockStorageV3 == nil {